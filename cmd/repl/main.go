@@ -0,0 +1,267 @@
+// Command repl is an interactive shell for experimenting with HashCircuit.
+// The constraint system and keys are created on first use and reused by every
+// later command.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/logger"
+
+	"hash_proof/hash_proof"
+)
+
+const helpText = `Commands:
+  hash <x>                 print MiMC(x)
+  prove <x> [--diagnostics out.json]
+                           prove knowledge of x for MiMC(x), writing redacted
+                           diagnostics to out.json if proving fails
+  verify                   verify the last proof
+  export solidity [path]   write the Solidity verifier (default HashProofVerifier.sol)
+  export abi [path]        write the verifier ABI of every circuit (default verifier_abi.json)
+  inspect --calldata 0x…   decode verifyProof calldata and diff it against the last proof
+  help                     show this message
+  exit                     leave the REPL`
+
+var errExit = errors.New("exit")
+
+type state struct {
+	curveID ecc.ID
+
+	ccs constraint.ConstraintSystem
+	pk  groth16.ProvingKey
+	vk  groth16.VerifyingKey
+
+	proof         groth16.Proof
+	publicWitness witness.Witness
+	hash          *big.Int
+}
+
+func newState() *state {
+	return &state{curveID: ecc.BN254}
+}
+
+// setup compiles the circuit and generates keys once per session.
+func (s *state) setup() error {
+	if s.ccs != nil {
+		return nil
+	}
+
+	var circuit hash_proof.HashCircuit
+	ccs, err := frontend.Compile(s.curveID.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		return err
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return err
+	}
+
+	s.ccs, s.pk, s.vk = ccs, pk, vk
+	return nil
+}
+
+func handleCommand(s *state, line string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	switch cmd, args := fields[0], fields[1:]; cmd {
+	case "help":
+		return helpText, nil
+
+	case "exit", "quit":
+		return "", errExit
+
+	case "hash":
+		x, err := parseArg(args)
+		if err != nil {
+			return "", err
+		}
+		hash, err := hash_proof.MiMCHash(s.curveID, x)
+		if err != nil {
+			return "", err
+		}
+		return hash.String(), nil
+
+	case "prove":
+		var diagnostics string
+		if len(args) == 3 && args[1] == "--diagnostics" {
+			args, diagnostics = args[:1], args[2]
+		}
+		x, err := parseArg(args)
+		if err != nil {
+			return "", err
+		}
+		assignment, public, err := hash_proof.NewAssignment("HashCircuit",
+			hash_proof.WithCurve(s.curveID),
+			hash_proof.WithSecret("PreImage", x),
+			hash_proof.WithAutoHash(),
+		)
+		if err != nil {
+			return "", err
+		}
+		hash := public[0]
+		if err := s.setup(); err != nil {
+			return "", err
+		}
+
+		w, err := frontend.NewWitness(assignment, s.curveID.ScalarField())
+		if err != nil {
+			return "", err
+		}
+		proof, err := groth16.Prove(s.ccs, s.pk, w)
+		if err != nil {
+			if diagnostics == "" {
+				return "", err
+			}
+			opts := hash_proof.DiagnosticsOptions{Curve: s.curveID, CCS: s.ccs}
+			if dErr := hash_proof.SaveDiagnostics(diagnostics, err, assignment, opts); dErr != nil {
+				return "", fmt.Errorf("%w (writing diagnostics failed: %v)", err, dErr)
+			}
+			return "", fmt.Errorf("%w (diagnostics written to %s)", err, diagnostics)
+		}
+		publicWitness, err := w.Public()
+		if err != nil {
+			return "", err
+		}
+
+		s.proof, s.publicWitness, s.hash = proof, publicWitness, hash
+		return fmt.Sprintf("proof generated for hash %s", hash), nil
+
+	case "verify":
+		if s.proof == nil {
+			return "", errors.New("no proof yet, run prove first")
+		}
+		if err := groth16.Verify(s.proof, s.vk, s.publicWitness); err != nil {
+			return "", fmt.Errorf("verification failed: %w", err)
+		}
+		return "proof is valid", nil
+
+	case "export":
+		if len(args) == 0 || (args[0] != "solidity" && args[0] != "abi") || len(args) > 2 {
+			return "", errors.New("usage: export solidity|abi [path]")
+		}
+		if args[0] == "abi" {
+			path := "verifier_abi.json"
+			if len(args) == 2 {
+				path = args[1]
+			}
+			var buf bytes.Buffer
+			if err := hash_proof.ExportABIRegistry(&buf); err != nil {
+				return "", err
+			}
+			if err := hash_proof.WriteFileAtomic(path, buf.Bytes(), 0644); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("verifier ABI written to %s (%d bytes)", path, buf.Len()), nil
+		}
+		path := "HashProofVerifier.sol"
+		if len(args) == 2 {
+			path = args[1]
+		}
+		if err := s.setup(); err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		if err := s.vk.ExportSolidity(&buf); err != nil {
+			return "", err
+		}
+		if err := hash_proof.WriteFileAtomic(path, buf.Bytes(), 0644); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Solidity verifier written to %s (%d bytes)", path, buf.Len()), nil
+
+	case "inspect":
+		if len(args) != 2 || args[0] != "--calldata" {
+			return "", errors.New("usage: inspect --calldata 0x…")
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(args[1], "0x"))
+		if err != nil {
+			return "", fmt.Errorf("invalid calldata: %w", err)
+		}
+		call, err := hash_proof.DecodeVerifyCalldata(data, "Hash")
+		if err != nil {
+			return "", err
+		}
+		out := call.String()
+		if s.proof == nil {
+			return out, nil
+		}
+		if local, err := s.localCall(); err == nil {
+			if diffs := call.Diff(local); len(diffs) == 0 {
+				out += "\nmatches the last proof"
+			} else {
+				out += "\ndiffers from the last proof:\n  " + strings.Join(diffs, "\n  ")
+			}
+		}
+		return out, nil
+
+	default:
+		return "", fmt.Errorf("unknown command %q, type help for a list", cmd)
+	}
+}
+
+// localCall decodes the calldata the last proof would be submitted with.
+func (s *state) localCall() (*hash_proof.DecodedCall, error) {
+	data, err := hash_proof.EncodeVerifyCalldata(s.proof, []*big.Int{s.hash})
+	if err != nil {
+		return nil, err
+	}
+	return hash_proof.DecodeVerifyCalldata(data, "Hash")
+}
+
+func parseArg(args []string) (*big.Int, error) {
+	if len(args) != 1 {
+		return nil, errors.New("expected exactly one integer argument")
+	}
+	x, ok := new(big.Int).SetString(args[0], 0)
+	if !ok || x.Sign() < 0 {
+		return nil, fmt.Errorf("invalid integer %q", args[0])
+	}
+	return x, nil
+}
+
+func main() {
+	// gnark's compile and prove logs would interleave with the prompt.
+	logger.Disable()
+
+	s := newState()
+	scanner := bufio.NewScanner(os.Stdin)
+
+	fmt.Println("ZK hash proof REPL, type help for commands")
+	for {
+		fmt.Print("zk> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+
+		out, err := handleCommand(s, scanner.Text())
+		if errors.Is(err, errExit) {
+			return
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			continue
+		}
+		if out != "" {
+			fmt.Println(out)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"hash_proof/hash_proof"
+)
+
+func TestHandleCommandProveVerify(t *testing.T) {
+	s := newState()
+
+	out, err := handleCommand(s, "hash 35")
+	if err != nil {
+		t.Fatalf("hash failed: %v", err)
+	}
+	if out != "2474112249751028531650252582366798049474486386634137916759752348728204118534" {
+		t.Fatalf("Unexpected hash: %s", out)
+	}
+
+	if _, err := handleCommand(s, "verify"); err == nil {
+		t.Fatal("verify before prove must fail")
+	}
+
+	out, err = handleCommand(s, "prove 35")
+	if err != nil {
+		t.Fatalf("prove failed: %v", err)
+	}
+	if !strings.Contains(out, "2474112249751028531650252582366798049474486386634137916759752348728204118534") {
+		t.Fatalf("Unexpected prove output: %s", out)
+	}
+
+	out, err = handleCommand(s, "verify")
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if out != "proof is valid" {
+		t.Fatalf("Unexpected verify output: %s", out)
+	}
+
+	// Keys are reused across commands.
+	vk := s.vk
+	if _, err := handleCommand(s, "prove 42"); err != nil {
+		t.Fatalf("prove failed: %v", err)
+	}
+	if s.vk != vk {
+		t.Fatal("Keys were regenerated between commands")
+	}
+	if _, err := handleCommand(s, "verify"); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+}
+
+func TestHandleCommandExportSolidity(t *testing.T) {
+	s := newState()
+	path := filepath.Join(t.TempDir(), "Verifier.sol")
+
+	out, err := handleCommand(s, "export solidity "+path)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(out, path) {
+		t.Fatalf("Unexpected export output: %s", out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read exported verifier: %v", err)
+	}
+	if !strings.Contains(string(data), "contract Verifier") {
+		t.Fatal("Exported file does not contain the Verifier contract")
+	}
+}
+
+func TestHandleCommandExportABI(t *testing.T) {
+	s := newState()
+	path := filepath.Join(t.TempDir(), "abi.json")
+
+	out, err := handleCommand(s, "export abi "+path)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(out, path) {
+		t.Fatalf("Unexpected export output: %s", out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read exported ABI: %v", err)
+	}
+	var registry map[string][]hash_proof.ABIEntry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		t.Fatalf("Exported ABI is not valid JSON: %v", err)
+	}
+	if _, ok := registry["HashCircuit[MiMC]"]; !ok {
+		t.Fatal("Exported ABI does not cover HashCircuit")
+	}
+}
+
+func TestHandleCommandInspect(t *testing.T) {
+	s := newState()
+
+	if _, err := handleCommand(s, "prove 35"); err != nil {
+		t.Fatalf("prove failed: %v", err)
+	}
+	local, err := s.localCall()
+	if err != nil {
+		t.Fatalf("Failed to encode the last proof: %v", err)
+	}
+
+	var words []byte
+	for _, v := range local.Proof {
+		words = append(words, v.FillBytes(make([]byte, 32))...)
+	}
+	words = append(words, big.NewInt(36).FillBytes(make([]byte, 32))...)
+
+	out, err := handleCommand(s, "inspect --calldata 0x"+hex.EncodeToString(words))
+	if err != nil {
+		t.Fatalf("inspect failed: %v", err)
+	}
+	if !strings.Contains(out, "Hash: 36") || !strings.Contains(out, "differs from the last proof:\n  Hash: 36, expected") {
+		t.Fatalf("Unexpected inspect output: %s", out)
+	}
+}
+
+func TestHandleCommandErrors(t *testing.T) {
+	s := newState()
+
+	for _, line := range []string{"frobnicate", "hash", "hash abc", "prove -1", "export", "export json", "inspect", "inspect --calldata 0xzz"} {
+		if _, err := handleCommand(s, line); err == nil {
+			t.Errorf("Expected an error for %q", line)
+		}
+	}
+
+	if out, err := handleCommand(s, "   "); err != nil || out != "" {
+		t.Fatalf("Blank lines must be ignored, got %q, %v", out, err)
+	}
+	if _, err := handleCommand(s, "exit"); !errors.Is(err, errExit) {
+		t.Fatalf("exit must return errExit, got %v", err)
+	}
+}
+
+// unsolvableCircuit has HashCircuit's witness layout but demands the preimage
+// equal its hash, so proving fails with the secret in the solver error.
+type unsolvableCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+}
+
+func (c *unsolvableCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.PreImage, c.Hash)
+	return nil
+}
+
+func TestHandleCommandProveDiagnostics(t *testing.T) {
+	s := newState()
+	if err := s.setup(); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	ccs, err := frontend.Compile(s.curveID.ScalarField(), r1cs.NewBuilder, &unsolvableCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	s.ccs = ccs
+
+	path := filepath.Join(t.TempDir(), "out.json")
+	_, err = handleCommand(s, "prove 35 --diagnostics "+path)
+	if err == nil || !strings.Contains(err.Error(), "diagnostics written to "+path) {
+		t.Fatalf("Expected a prove failure with diagnostics, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read diagnostics: %v", err)
+	}
+	var d hash_proof.DiagnosticsBundle
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatalf("Failed to unmarshal diagnostics: %v", err)
+	}
+	if d.Circuit != "HashCircuit[MiMC]" || d.Constraints != ccs.GetNbConstraints() ||
+		!strings.Contains(d.Error, hash_proof.Redacted) || strings.Contains(d.Error, " 35 ") {
+		t.Fatalf("Unexpected diagnostics: %+v", d)
+	}
+
+	if _, err := handleCommand(s, "prove 35 --diagnostics"); err == nil {
+		t.Fatal("Expected an error without a diagnostics path")
+	}
+}
@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+// Command wasm exposes proof verification to JavaScript, so front-ends can
+// check a proof locally without a chain. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o verifier.wasm ./cmd/wasm
+//
+// and call zkVerify(proofHex, vkHex, inputJSON) once the module has started.
+package main
+
+import (
+	"syscall/js"
+
+	"hash_proof/hash_proof"
+)
+
+// Verify returns "true" or "false", or the error message when the arguments
+// cannot be decoded. See hash_proof.VerifyHex for the argument formats.
+func Verify(proofHex, vkHex, inputJSON string) string {
+	ok, err := hash_proof.VerifyHex(proofHex, vkHex, inputJSON)
+	if err != nil {
+		return err.Error()
+	}
+	if ok {
+		return "true"
+	}
+	return "false"
+}
+
+func main() {
+	js.Global().Set("zkVerify", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) != 3 {
+			return "zkVerify expects proofHex, vkHex and inputJSON"
+		}
+		return Verify(args[0].String(), args[1].String(), args[2].String())
+	}))
+	select {}
+}
@@ -3,42 +3,50 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
-	"github.com/consensys/gnark/std/hash/mimc"
-)
 
-type Circuit struct {
-	PreImage frontend.Variable `gnark:",secret"`
-	Hash     frontend.Variable `gnark:",public"`
-}
+	"hash_proof/hash_proof"
+)
 
-func (c *Circuit) Define(api frontend.API) error {
-	hFunc, err := mimc.NewMiMC(api)
-	if err != nil {
-		return err
-	}
-	hFunc.Write(c.PreImage)
-	computedHash := hFunc.Sum()
-	api.AssertIsEqual(c.Hash, computedHash)
-	return nil
-}
+var (
+	dryRun      = flag.Bool("dry-run", false, "print the estimated setup cost and exit without generating keys")
+	proofLog    = flag.String("proof-log", "", "append a transcript entry for the generated proof to this file")
+	outDir      = flag.String("out-dir", ".", "directory to write the Solidity verifier and Remix values to")
+	estimate    = flag.Bool("estimate", false, "print the estimated verification gas and calldata size after setup and exit without proving")
+	armorVK     = flag.Bool("armored-vk", false, "also write the verifying key in armored form to verifying_key.asc")
+	diagnostics = flag.String("diagnostics", "", "write redacted diagnostics to this file if proving fails")
+)
 
 func main() {
+	flag.Parse()
+
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
 	fmt.Println("║  ZK Hash Proof Generator for Remix On-Chain Verification  ║")
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
 	fmt.Println()
 
 	// Configuration
+	solidityPath := filepath.Join(*outDir, "HashProofVerifier.sol")
+	remixPath := filepath.Join(*outDir, "remix_proof_values.json")
 	preImage := 35
-	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	assignment, publicInputs, err := hash_proof.NewAssignment(hash_proof.CircuitName(&hash_proof.HashCircuit{}),
+		hash_proof.WithSecret("PreImage", big.NewInt(int64(preImage))),
+		hash_proof.WithAutoHash(),
+	)
+	if err != nil {
+		fmt.Printf("❌ Error building assignment: %v\n", err)
+		return
+	}
+	hash := publicInputs[0].String()
 
 	fmt.Printf("📋 Configuration:\n")
 	fmt.Printf("   Secret PreImage (x): %d\n", preImage)
@@ -47,8 +55,7 @@ func main() {
 
 	// Step 1: Compile Circuit
 	fmt.Println("🔨 Step 1: Compiling circuit...")
-	var circuit Circuit
-	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hash_proof.HashCircuit{})
 	if err != nil {
 		fmt.Printf("❌ Error compiling circuit: %v\n", err)
 		return
@@ -56,6 +63,32 @@ func main() {
 	fmt.Printf("   ✅ Circuit compiled (%d constraints)\n", ccs.GetNbConstraints())
 	fmt.Println()
 
+	if *dryRun {
+		fmt.Println("📐 Dry run: estimating setup...")
+		calibration, err := hash_proof.LoadOrCalibrate(hash_proof.DefaultCalibrationPath(ecc.BN254), ecc.BN254)
+		if err != nil {
+			fmt.Printf("❌ Error calibrating: %v\n", err)
+			return
+		}
+		estimate, err := calibration.Estimate(ccs)
+		if err != nil {
+			fmt.Printf("❌ Error estimating setup: %v\n", err)
+			return
+		}
+		printBound := func(label string, b hash_proof.Bound, unit string) {
+			fmt.Printf("   %-20s %.3g %s (%.3g – %.3g)\n", label, b.Expected, unit, b.Low, b.High)
+		}
+		printBound("Proving key:", estimate.ProvingKeyBytes, "bytes")
+		printBound("Verifying key:", estimate.VerifyingKeyBytes, "bytes")
+		printBound("Setup time:", estimate.SetupSeconds, "s")
+		printBound("Prove time:", estimate.ProveSeconds, "s")
+		printBound("Setup allocations:", estimate.SetupAllocBytes, "bytes")
+		printBound("Prove allocations:", estimate.ProveAllocBytes, "bytes")
+		fmt.Println()
+		fmt.Println("✅ Dry run complete, no keys were generated.")
+		return
+	}
+
 	// Step 2: Setup (CRITICAL: This generates VK for Solidity AND pk for proof)
 	fmt.Println("⚙️  Step 2: Setting up Groth16...")
 	pk, vk, err := groth16.Setup(ccs)
@@ -66,6 +99,22 @@ func main() {
 	fmt.Println("   ✅ Setup complete")
 	fmt.Println()
 
+	if *estimate {
+		fmt.Println("⛽ Estimating on-chain verification...")
+		nbCommitments := len(ccs.GetCommitments().CommitmentIndexes())
+		gas, err := hash_proof.EstimateVerifyGas(vk.NbPublicWitness()-nbCommitments, nbCommitments)
+		if err != nil {
+			fmt.Printf("❌ Error estimating gas: %v\n", err)
+			return
+		}
+		fmt.Printf("   Calldata:       %d bytes (%d gas)\n", gas.CalldataBytes, gas.CalldataGas)
+		fmt.Printf("   Execution:      %d gas\n", gas.ExecutionGas)
+		fmt.Printf("   Total:          %d gas\n", gas.TotalGas)
+		fmt.Println()
+		fmt.Println("✅ Estimate complete, no proof was generated.")
+		return
+	}
+
 	// Step 3: Export Solidity Verifier (use SAME vk from step 2)
 	fmt.Println("📜 Step 3: Exporting Solidity verifier...")
 	var solidityBuf bytes.Buffer
@@ -75,21 +124,32 @@ func main() {
 		return
 	}
 
-	err = os.WriteFile("HashProofVerifier.sol", solidityBuf.Bytes(), 0644)
+	err = os.MkdirAll(*outDir, 0755)
+	if err != nil {
+		fmt.Printf("❌ Error creating output directory: %v\n", err)
+		return
+	}
+
+	err = hash_proof.WriteFileAtomic(solidityPath, solidityBuf.Bytes(), 0644)
 	if err != nil {
 		fmt.Printf("❌ Error writing Solidity file: %v\n", err)
 		return
 	}
-	fmt.Printf("   ✅ Solidity verifier written to HashProofVerifier.sol (%d bytes)\n", solidityBuf.Len())
+	fmt.Printf("   ✅ Solidity verifier written to %s (%d bytes)\n", solidityPath, solidityBuf.Len())
+
+	if *armorVK {
+		vkPath := filepath.Join(*outDir, "verifying_key.asc")
+		err = hash_proof.SaveArmoredKey(vkPath, vk, hash_proof.LabelVerifyingKey)
+		if err != nil {
+			fmt.Printf("❌ Error writing armored verifying key: %v\n", err)
+			return
+		}
+		fmt.Printf("   ✅ Armored verifying key written to %s\n", vkPath)
+	}
 	fmt.Println()
 
 	// Step 4: Create Witness
 	fmt.Println("📝 Step 4: Creating witness...")
-	assignment := &Circuit{
-		PreImage: preImage,
-		Hash:     hash,
-	}
-
 	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
 	if err != nil {
 		fmt.Printf("❌ Error creating witness: %v\n", err)
@@ -103,6 +163,14 @@ func main() {
 	proof, err := groth16.Prove(ccs, pk, witness)
 	if err != nil {
 		fmt.Printf("❌ Error generating proof: %v\n", err)
+		if *diagnostics != "" {
+			opts := hash_proof.DiagnosticsOptions{Curve: ecc.BN254, CCS: ccs}
+			if dErr := hash_proof.SaveDiagnostics(*diagnostics, err, assignment, opts); dErr != nil {
+				fmt.Printf("❌ Error writing diagnostics: %v\n", dErr)
+				return
+			}
+			fmt.Printf("   📋 Diagnostics written to %s\n", *diagnostics)
+		}
 		return
 	}
 	fmt.Println("   ✅ Proof generated")
@@ -124,6 +192,27 @@ func main() {
 	fmt.Println("   ✅ Off-chain verification successful")
 	fmt.Println()
 
+	if *proofLog != "" {
+		entry, err := hash_proof.NewProofLogEntry(ccs, vk, proof, publicWitness)
+		if err != nil {
+			fmt.Printf("❌ Error creating transcript entry: %v\n", err)
+			return
+		}
+		f, err := os.OpenFile(*proofLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			fmt.Printf("❌ Error opening transcript: %v\n", err)
+			return
+		}
+		err = hash_proof.LogProof(f, entry)
+		f.Close()
+		if err != nil {
+			fmt.Printf("❌ Error writing transcript: %v\n", err)
+			return
+		}
+		fmt.Printf("   📒 Proof recorded in %s\n", *proofLog)
+		fmt.Println()
+	}
+
 	// Step 7: Serialize Proof
 	fmt.Println("📦 Step 7: Serializing proof...")
 	var proofBuf bytes.Buffer
@@ -164,12 +253,12 @@ func main() {
 	output.FullHex = fmt.Sprintf("0x%x", proofBytes)
 
 	jsonData, _ := json.MarshalIndent(output, "", "  ")
-	err = os.WriteFile("remix_proof_values.json", jsonData, 0644)
+	err = hash_proof.WriteFileAtomic(remixPath, jsonData, 0644)
 	if err != nil {
 		fmt.Printf("❌ Error writing JSON: %v\n", err)
 		return
 	}
-	fmt.Println("   ✅ Remix values saved to remix_proof_values.json")
+	fmt.Printf("   ✅ Remix values saved to %s\n", remixPath)
 	fmt.Println()
 
 	// Display Results
@@ -178,8 +267,8 @@ func main() {
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
 	fmt.Println()
 	fmt.Println("📁 Files Generated:")
-	fmt.Println("   1. HashProofVerifier.sol - Deploy this to Remix")
-	fmt.Println("   2. remix_proof_values.json - Copy these values to Remix")
+	fmt.Printf("   1. %s - Deploy this to Remix\n", solidityPath)
+	fmt.Printf("   2. %s - Copy these values to Remix\n", remixPath)
 	fmt.Println()
 	fmt.Println("🔗 Remix Instructions:")
 	fmt.Println("   1. Open https://remix.ethereum.org")
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDryRunWritesNothing(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	*dryRun = true
+	defer func() { *dryRun = false }()
+
+	main()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read output directory: %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("Dry run wrote %s", e.Name())
+	}
+}
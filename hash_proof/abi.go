@@ -0,0 +1,153 @@
+package hash_proof
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"golang.org/x/crypto/sha3"
+)
+
+// ABIParam is a parameter of an ABI entry.
+type ABIParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ABIEntry is a function or error of a contract ABI, in the JSON layout solc
+// and abigen use.
+type ABIEntry struct {
+	Type            string     `json:"type"`
+	Name            string     `json:"name"`
+	Inputs          []ABIParam `json:"inputs"`
+	Outputs         []ABIParam `json:"outputs,omitempty"`
+	StateMutability string     `json:"stateMutability,omitempty"`
+}
+
+// Signature returns the canonical signature of e, e.g.
+// verifyProof(uint256[8],uint256[1]).
+func (e ABIEntry) Signature() string {
+	types := make([]string, len(e.Inputs))
+	for i, in := range e.Inputs {
+		types[i] = in.Type
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(types, ","))
+}
+
+// Selector returns the four-byte selector of e.
+func (e ABIEntry) Selector() []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(e.Signature()))
+	return h.Sum(nil)[:4]
+}
+
+// VerifierABI returns the ABI of the Groth16 BN254 verifier gnark exports for
+// a circuit with nbPublicInputs public inputs and nbCommitments BSB22
+// commitments, which circuits using rangecheck have. Commitments and their
+// proof of knowledge are passed alongside the proof. The verifying functions
+// return nothing; they revert on failure.
+func VerifierABI(nbPublicInputs, nbCommitments int) []ABIEntry {
+	input := ABIParam{Name: "input", Type: fmt.Sprintf("uint256[%d]", nbPublicInputs)}
+	errs := []ABIEntry{
+		{Type: "error", Name: "ProofInvalid", Inputs: []ABIParam{}},
+		{Type: "error", Name: "PublicInputNotInField", Inputs: []ABIParam{}},
+	}
+	if nbCommitments == 0 {
+		return append(errs,
+			ABIEntry{
+				Type:            "function",
+				Name:            "compressProof",
+				Inputs:          []ABIParam{{Name: "proof", Type: "uint256[8]"}},
+				Outputs:         []ABIParam{{Name: "compressed", Type: "uint256[4]"}},
+				StateMutability: "view",
+			},
+			ABIEntry{
+				Type:            "function",
+				Name:            "verifyCompressedProof",
+				Inputs:          []ABIParam{{Name: "compressedProof", Type: "uint256[4]"}, input},
+				StateMutability: "view",
+			},
+			ABIEntry{
+				Type:            "function",
+				Name:            "verifyProof",
+				Inputs:          []ABIParam{{Name: "proof", Type: "uint256[8]"}, input},
+				StateMutability: "view",
+			},
+		)
+	}
+
+	commitments := ABIParam{Name: "commitments", Type: fmt.Sprintf("uint256[%d]", 2*nbCommitments)}
+	commitmentPok := ABIParam{Name: "commitmentPok", Type: "uint256[2]"}
+	compressedCommitments := ABIParam{Name: "compressedCommitments", Type: fmt.Sprintf("uint256[%d]", nbCommitments)}
+	compressedCommitmentPok := ABIParam{Name: "compressedCommitmentPok", Type: "uint256"}
+	return append(errs,
+		ABIEntry{Type: "error", Name: "CommitmentInvalid", Inputs: []ABIParam{}},
+		ABIEntry{
+			Type:            "function",
+			Name:            "compressProof",
+			Inputs:          []ABIParam{{Name: "proof", Type: "uint256[8]"}, commitments, commitmentPok},
+			Outputs:         []ABIParam{{Name: "compressed", Type: "uint256[4]"}, compressedCommitments, compressedCommitmentPok},
+			StateMutability: "view",
+		},
+		ABIEntry{
+			Type:            "function",
+			Name:            "verifyCompressedProof",
+			Inputs:          []ABIParam{{Name: "compressedProof", Type: "uint256[4]"}, compressedCommitments, compressedCommitmentPok, input},
+			StateMutability: "view",
+		},
+		ABIEntry{
+			Type:            "function",
+			Name:            "verifyProof",
+			Inputs:          []ABIParam{{Name: "proof", Type: "uint256[8]"}, commitments, commitmentPok, input},
+			StateMutability: "view",
+		},
+	)
+}
+
+// verifierFunction returns the entry of VerifierABI(nbPublicInputs, 0)
+// called name.
+func verifierFunction(nbPublicInputs int, name string) ABIEntry {
+	for _, e := range VerifierABI(nbPublicInputs, 0) {
+		if e.Type == "function" && e.Name == name {
+			return e
+		}
+	}
+	panic("verifier ABI has no function " + name)
+}
+
+// CircuitVerifierABI returns the verifier ABI for circuit. The circuit is
+// compiled over BN254 to count its commitments.
+func CircuitVerifierABI(circuit frontend.Circuit) ([]ABIEntry, error) {
+	names, err := publicInputNames(circuit, ecc.BN254)
+	if err != nil {
+		return nil, err
+	}
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, err
+	}
+	return VerifierABI(len(names), len(ccs.GetCommitments().CommitmentIndexes())), nil
+}
+
+// ExportABIRegistry writes the verifier ABI of every circuit NewAssignment
+// knows as a JSON object keyed by circuit name.
+func ExportABIRegistry(w io.Writer) error {
+	registry := make(map[string][]ABIEntry, len(assignableCircuits))
+	for name, newCircuit := range assignableCircuits {
+		abi, err := CircuitVerifierABI(newCircuit())
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		registry[name] = abi
+	}
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,134 @@
+package hash_proof
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+var (
+	solidityFunctionDecl = regexp.MustCompile(`function\s+(\w+)\s*\(([^)]*)\)\s*public\s+(view|pure)\s*(?:returns\s*\(([^)]*)\))?`)
+	solidityErrorDecl    = regexp.MustCompile(`error\s+(\w+)\s*\(\s*\)\s*;`)
+)
+
+// parseSolidityABI extracts the public functions and errors declared in a
+// gnark-exported verifier, in declaration order.
+func parseSolidityABI(t *testing.T, src string) []ABIEntry {
+	params := func(list string) []ABIParam {
+		out := []ABIParam{}
+		for _, p := range strings.Split(list, ",") {
+			fields := strings.Fields(p)
+			if len(fields) == 0 {
+				continue
+			}
+			// type [data location] name
+			out = append(out, ABIParam{Name: fields[len(fields)-1], Type: fields[0]})
+		}
+		return out
+	}
+
+	var abi []ABIEntry
+	for _, m := range solidityErrorDecl.FindAllStringSubmatch(src, -1) {
+		abi = append(abi, ABIEntry{Type: "error", Name: m[1], Inputs: []ABIParam{}})
+	}
+	for _, m := range solidityFunctionDecl.FindAllStringSubmatch(src, -1) {
+		e := ABIEntry{Type: "function", Name: m[1], Inputs: params(m[2]), StateMutability: m[3]}
+		if outputs := params(m[4]); len(outputs) > 0 {
+			e.Outputs = outputs
+		}
+		abi = append(abi, e)
+	}
+	if len(abi) == 0 {
+		t.Fatal("No declarations found in the exported verifier")
+	}
+	return abi
+}
+
+func sortABI(abi []ABIEntry) {
+	slices.SortFunc(abi, func(a, b ABIEntry) int {
+		return strings.Compare(a.Type+" "+a.Name, b.Type+" "+b.Name)
+	})
+}
+
+func TestVerifierABIMatchesExportedContract(t *testing.T) {
+	for name, newCircuit := range assignableCircuits {
+		t.Run(name, func(t *testing.T) {
+			ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, newCircuit())
+			if err != nil {
+				t.Fatalf("Failed to compile circuit: %v", err)
+			}
+			_, vk, err := groth16.Setup(ccs)
+			if err != nil {
+				t.Fatalf("Failed to setup: %v", err)
+			}
+			var solidityBuf bytes.Buffer
+			if err := vk.ExportSolidity(&solidityBuf); err != nil {
+				t.Fatalf("Failed to export Solidity verifier: %v", err)
+			}
+
+			exported := parseSolidityABI(t, solidityBuf.String())
+			abi, err := CircuitVerifierABI(newCircuit())
+			if err != nil {
+				t.Fatalf("Failed to build verifier ABI: %v", err)
+			}
+			sortABI(exported)
+			sortABI(abi)
+			if !reflect.DeepEqual(exported, abi) {
+				t.Fatalf("Verifier ABI does not match the exported contract:\ngot  %+v\nwant %+v", abi, exported)
+			}
+		})
+	}
+
+	if got := verifierFunction(1, "verifyProof").Signature(); got != "verifyProof(uint256[8],uint256[1])" {
+		t.Fatalf("Unexpected signature %s", got)
+	}
+	commitment := VerifierABI(2, 1)
+	if got := commitment[len(commitment)-1].Signature(); got != "verifyProof(uint256[8],uint256[2],uint256[2],uint256[2])" {
+		t.Fatalf("Unexpected signature %s", got)
+	}
+}
+
+func TestABIRegistryGolden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportABIRegistry(&buf); err != nil {
+		t.Fatalf("Failed to export ABI registry: %v", err)
+	}
+
+	path := filepath.Join("testdata", "abi_registry.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), golden) {
+		t.Fatalf("%s is stale; regenerate it with go test -run TestABIRegistryGolden -update", path)
+	}
+
+	// The input array length follows the public-input count.
+	abi, err := CircuitVerifierABI(&MedianHashCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to build verifier ABI: %v", err)
+	}
+	for _, e := range abi {
+		if e.Name == "verifyProof" && e.Signature() != "verifyProof(uint256[8],uint256[4])" {
+			t.Fatalf("Unexpected signature %s", e.Signature())
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package hash_proof
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// AppendMerkleCircuit proves that writing the leaf MiMC(PreImage) at the
+// empty position Index of the tree with root OldRoot gives the tree with root
+// NewRoot. Empty leaves are zero, and the same siblings authenticate the
+// position before and after the write.
+type AppendMerkleCircuit struct {
+	PreImage frontend.Variable              `gnark:",secret"`
+	Siblings [MerkleDepth]frontend.Variable `gnark:",secret"`
+	OldRoot  frontend.Variable              `gnark:",public"`
+	NewRoot  frontend.Variable              `gnark:",public"`
+	Index    frontend.Variable              `gnark:",public"`
+}
+
+func (circuit *AppendMerkleCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	leaf := hFunc.Sum()
+
+	isRight := api.ToBinary(circuit.Index, MerkleDepth)
+	oldNode, err := merkleRoot(api, 0, circuit.Siblings[:], isRight)
+	if err != nil {
+		return err
+	}
+	newNode, err := merkleRoot(api, leaf, circuit.Siblings[:], isRight)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.OldRoot, oldNode)
+	api.AssertIsEqual(circuit.NewRoot, newNode)
+
+	return nil
+}
+
+// IncrementalMerkleTree is an append-only tree of 2^MerkleDepth leaves, the
+// native counterpart of AppendMerkleCircuit.
+type IncrementalMerkleTree struct {
+	curveID ecc.ID
+	leaves  []*big.Int
+	next    int
+}
+
+// NewIncrementalMerkleTree returns an empty tree.
+func NewIncrementalMerkleTree(curveID ecc.ID) *IncrementalMerkleTree {
+	leaves := make([]*big.Int, 1<<MerkleDepth)
+	for i := range leaves {
+		leaves[i] = new(big.Int)
+	}
+	return &IncrementalMerkleTree{curveID: curveID, leaves: leaves}
+}
+
+// Root returns the current root of the tree.
+func (t *IncrementalMerkleTree) Root() (*big.Int, error) {
+	levels, err := merkleLevelsFromLeaves(t.curveID, t.leaves)
+	if err != nil {
+		return nil, err
+	}
+	return levels[MerkleDepth][0], nil
+}
+
+// Append writes MiMC(secret) at the next empty position and returns the
+// assignment proving the update.
+func (t *IncrementalMerkleTree) Append(secret *big.Int) (*AppendMerkleCircuit, error) {
+	if t.next == len(t.leaves) {
+		return nil, errors.New("merkle tree is full")
+	}
+
+	levels, err := merkleLevelsFromLeaves(t.curveID, t.leaves)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &AppendMerkleCircuit{
+		PreImage: secret,
+		OldRoot:  levels[MerkleDepth][0],
+		Index:    t.next,
+	}
+	for i, index := 0, t.next; i < MerkleDepth; i, index = i+1, index>>1 {
+		assignment.Siblings[i] = levels[i][index^1]
+	}
+
+	leaf, err := MiMCHash(t.curveID, secret)
+	if err != nil {
+		return nil, err
+	}
+	t.leaves[t.next] = leaf
+	t.next++
+
+	if assignment.NewRoot, err = t.Root(); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
@@ -0,0 +1,50 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestAppendMerkleCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	tree := NewIncrementalMerkleTree(ecc.BN254)
+	var circuit AppendMerkleCircuit
+
+	var last *AppendMerkleCircuit
+	for _, secret := range []int64{35, 36, 37} {
+		assignment, err := tree.Append(big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to append leaf: %v", err)
+		}
+		if last != nil && assignment.OldRoot.(*big.Int).Cmp(last.NewRoot.(*big.Int)) != 0 {
+			t.Fatal("Old root of an append is not the new root of the previous one")
+		}
+		assert.ProverSucceeded(&circuit, assignment, test.WithCurves(ecc.BN254))
+		last = assignment
+	}
+
+	// Appending at an occupied position must fail.
+	occupied := *last
+	occupied.Index = 1
+	assert.ProverFailed(&circuit, &occupied, test.WithCurves(ecc.BN254))
+
+	wrongRoot := *last
+	wrongRoot.NewRoot = wrongRoot.OldRoot
+	assert.ProverFailed(&circuit, &wrongRoot, test.WithCurves(ecc.BN254))
+}
+
+func TestIncrementalMerkleTreeFull(t *testing.T) {
+	tree := NewIncrementalMerkleTree(ecc.BN254)
+	for i := 0; i < 1<<MerkleDepth; i++ {
+		if _, err := tree.Append(big.NewInt(int64(i))); err != nil {
+			t.Fatalf("Failed to append leaf %d: %v", i, err)
+		}
+	}
+	if _, err := tree.Append(big.NewInt(0)); err == nil {
+		t.Fatal("Expected appending to a full tree to fail")
+	}
+}
@@ -0,0 +1,69 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Labels of armored key blocks.
+const (
+	LabelVerifyingKey = "VERIFYING KEY"
+	LabelProvingKey   = "PROVING KEY"
+)
+
+const armorPrefix = "ZK "
+
+// ArmorKey encodes data as a base64 block between
+// -----BEGIN ZK <label>----- and -----END ZK <label>----- lines, so keys can
+// be pasted into config files.
+func ArmorKey(data []byte, label string) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: armorPrefix + label, Bytes: data}))
+}
+
+// DearmorKey decodes the first armored block in s and returns its data and
+// label.
+func DearmorKey(s string) ([]byte, string, error) {
+	block, rest := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, "", errors.New("no armored key block found")
+	}
+	if !strings.HasPrefix(block.Type, armorPrefix) {
+		return nil, "", fmt.Errorf("%q is not a ZK key block", block.Type)
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, "", errors.New("trailing data after armored key block")
+	}
+	return block.Bytes, strings.TrimPrefix(block.Type, armorPrefix), nil
+}
+
+// SaveArmoredKey atomically writes key to path in armored form.
+func SaveArmoredKey(path string, key io.WriterTo, label string) error {
+	var buf bytes.Buffer
+	if _, err := key.WriteTo(&buf); err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, []byte(ArmorKey(buf.Bytes(), label)), 0644)
+}
+
+// LoadArmoredKey reads an armored key from path into key, checking that the
+// block carries the expected label.
+func LoadArmoredKey(path string, key io.ReaderFrom, label string) error {
+	s, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, got, err := DearmorKey(string(s))
+	if err != nil {
+		return err
+	}
+	if got != label {
+		return fmt.Errorf("%s holds a %s, expected a %s", path, got, label)
+	}
+	_, err = key.ReadFrom(bytes.NewReader(data))
+	return err
+}
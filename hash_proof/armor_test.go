@@ -0,0 +1,63 @@
+package hash_proof_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestArmoredVerifyingKey(t *testing.T) {
+	_, _, vk, proof, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+
+	path := filepath.Join(t.TempDir(), "verifying_key.asc")
+	if err := hash_proof.SaveArmoredKey(path, vk, hash_proof.LabelVerifyingKey); err != nil {
+		t.Fatalf("Failed to save armored key: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read armored key: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "-----BEGIN ZK VERIFYING KEY-----\n") {
+		t.Fatalf("Unexpected armor header: %q", strings.SplitN(string(data), "\n", 2)[0])
+	}
+
+	loaded := groth16.NewVerifyingKey(ecc.BN254)
+	if err := hash_proof.LoadArmoredKey(path, loaded, hash_proof.LabelVerifyingKey); err != nil {
+		t.Fatalf("Failed to load armored key: %v", err)
+	}
+
+	err = groth16.Verify(proof, loaded, publicWitness)
+	if err != nil {
+		t.Fatalf("Failed to verify proof with the dearmored key: %v", err)
+	}
+
+	if err := hash_proof.LoadArmoredKey(path, groth16.NewProvingKey(ecc.BN254), hash_proof.LabelProvingKey); err == nil {
+		t.Fatal("Expected a verifying key block to be rejected as a proving key")
+	}
+}
+
+func TestDearmorKeyErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"not armored",
+		"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n",
+		hash_proof.ArmorKey([]byte{1, 2, 3}, hash_proof.LabelVerifyingKey) + "trailing",
+	} {
+		if _, _, err := hash_proof.DearmorKey(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+
+	data, label, err := hash_proof.DearmorKey(hash_proof.ArmorKey([]byte{1, 2, 3}, hash_proof.LabelProvingKey))
+	if err != nil || label != hash_proof.LabelProvingKey || string(data) != "\x01\x02\x03" {
+		t.Fatalf("Unexpected round trip: %v %q %v", data, label, err)
+	}
+}
@@ -0,0 +1,239 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// assignableCircuits are the circuits NewAssignment can build, by type name.
+// Every circuit in the package whose inputs are all scalar variables is
+// listed; circuits with array or nested inputs need their own constructors.
+var assignableCircuits = map[string]func() frontend.Circuit{
+	"SPNCommitCircuit":          func() frontend.Circuit { return &SPNCommitCircuit{} },
+	"CoprimeHashCircuit":        func() frontend.Circuit { return &CoprimeHashCircuit{} },
+	"CommittedThresholdCircuit": func() frontend.Circuit { return &CommittedThresholdCircuit{} },
+	"RotationCircuit":           func() frontend.Circuit { return &RotationCircuit{} },
+	"ValidDateHashCircuit":      func() frontend.Circuit { return &ValidDateHashCircuit{} },
+	"HashDeltaCircuit":          func() frontend.Circuit { return &HashDeltaCircuit{} },
+	"OrderedHashCircuit":        func() frontend.Circuit { return &OrderedHashCircuit{} },
+	"PedersenMiMCCircuit":       func() frontend.Circuit { return &PedersenMiMCCircuit{} },
+	"LinearSystemCircuit":       func() frontend.Circuit { return &LinearSystemCircuit{} },
+	"MetadataHashCircuit":       func() frontend.Circuit { return &MetadataHashCircuit{} },
+	"ModExpHashCircuit":         func() frontend.Circuit { return &ModExpHashCircuit{} },
+	"BridgeCircuit":             func() frontend.Circuit { return &BridgeCircuit{} },
+	"CollatzHashCircuit":        func() frontend.Circuit { return &CollatzHashCircuit{} },
+	"DifferenceCircuit":         func() frontend.Circuit { return &DifferenceCircuit{} },
+	"QRHashCircuit":             func() frontend.Circuit { return &QRHashCircuit{} },
+}
+
+// circuitCurves lists the Groth16 curves of registered circuits that do not
+// build on all of groth16Curves. Pedersen runs on Baby Jubjub, which is
+// defined over BN254's scalar field, and Poseidon2 only has parameters for
+// some curves. TestCircuitCurves compiles every circuit to keep this honest.
+var circuitCurves = map[string][]ecc.ID{
+	"PedersenMiMCCircuit": {ecc.BN254},
+	"BridgeCircuit":       poseidon2Curves(),
+	CircuitName(&HashCircuitOf[PoseidonGadget]{}): poseidon2Curves(),
+}
+
+// circuitSupports reports whether the registered circuit name builds on
+// curveID.
+func circuitSupports(name string, curveID ecc.ID) bool {
+	curves, ok := circuitCurves[name]
+	if !ok {
+		return slices.Contains(groth16Curves, curveID)
+	}
+	return slices.Contains(curves, curveID)
+}
+
+// circuitAliases are names NewAssignment and manifests still accept for
+// registered circuits, e.g. HashCircuit from before it became generic.
+var circuitAliases = map[string]string{
+	"HashCircuit": CircuitName(&HashCircuit{}),
+}
+
+func init() {
+	// Generic circuits are registered once per gadget under their
+	// CircuitName.
+	for _, newCircuit := range []func() frontend.Circuit{
+		func() frontend.Circuit { return &HashCircuitOf[MiMCGadget]{} },
+		func() frontend.Circuit { return &HashCircuitOf[PoseidonGadget]{} },
+	} {
+		assignableCircuits[CircuitName(newCircuit())] = newCircuit
+	}
+}
+
+// lookupCircuit returns the registered constructor for name or its alias.
+func lookupCircuit(name string) (func() frontend.Circuit, bool) {
+	if canonical, ok := circuitAliases[name]; ok {
+		name = canonical
+	}
+	newCircuit, ok := assignableCircuits[name]
+	return newCircuit, ok
+}
+
+// AssignOption sets inputs of an assignment built by NewAssignment.
+type AssignOption func(*assignmentBuilder) error
+
+type assignmentBuilder struct {
+	curveID  ecc.ID
+	values   map[string]*big.Int
+	public   map[string]bool
+	autoHash bool
+	nonZero  bool
+}
+
+func (b *assignmentBuilder) set(name string, v *big.Int, public bool) error {
+	if _, ok := b.values[name]; ok {
+		return fmt.Errorf("input %s is set twice", name)
+	}
+	b.values[name] = new(big.Int).Set(v)
+	b.public[name] = public
+	return nil
+}
+
+// WithSecret assigns v to the secret input name.
+func WithSecret(name string, v *big.Int) AssignOption {
+	return func(b *assignmentBuilder) error { return b.set(name, v, false) }
+}
+
+// WithPublic assigns v to the public input name.
+func WithPublic(name string, v *big.Int) AssignOption {
+	return func(b *assignmentBuilder) error { return b.set(name, v, true) }
+}
+
+// WithBytes assigns the big-endian integer of data to the input name,
+// whatever its visibility.
+func WithBytes(name string, data []byte) AssignOption {
+	return func(b *assignmentBuilder) error {
+		if _, ok := b.values[name]; ok {
+			return fmt.Errorf("input %s is set twice", name)
+		}
+		b.values[name] = new(big.Int).SetBytes(data)
+		return nil
+	}
+}
+
+// WithAutoHash derives the public Hash input as MiMC(PreImage).
+func WithAutoHash() AssignOption {
+	return func(b *assignmentBuilder) error {
+		b.autoHash = true
+		return nil
+	}
+}
+
+// WithNonZeroSecrets rejects assignments with a secret input of zero, the
+// value an uninitialized variable silently takes. Zero is a valid field
+// element, so the check is off by default.
+func WithNonZeroSecrets() AssignOption {
+	return func(b *assignmentBuilder) error {
+		b.nonZero = true
+		return nil
+	}
+}
+
+// WithCurve selects the curve hashes are derived over and inputs are checked
+// against. The default is BN254.
+func WithCurve(curveID ecc.ID) AssignOption {
+	return func(b *assignmentBuilder) error {
+		b.curveID = curveID
+		return nil
+	}
+}
+
+// NewAssignment builds an assignment of the named circuit. Every option is
+// checked against the circuit's inputs, and every input must be set. It also
+// returns the public inputs in the order the verifier expects them.
+func NewAssignment(circuitName string, opts ...AssignOption) (frontend.Circuit, []*big.Int, error) {
+	newCircuit, ok := lookupCircuit(circuitName)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown circuit %q", circuitName)
+	}
+
+	b := &assignmentBuilder{
+		curveID: ecc.BN254,
+		values:  map[string]*big.Int{},
+		public:  map[string]bool{},
+	}
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	circuit := newCircuit()
+	fields := reflect.ValueOf(circuit).Elem()
+	schema := fields.Type()
+
+	if b.autoHash {
+		if _, ok := schema.FieldByName("Hash"); !ok {
+			return nil, nil, fmt.Errorf("%s has no Hash input to derive", circuitName)
+		}
+		preImage, ok := b.values["PreImage"]
+		if !ok {
+			return nil, nil, fmt.Errorf("auto hash needs PreImage to be set")
+		}
+		gadget := circuitGadget(circuit)
+		hash, err := GadgetHash(gadget, b.curveID, preImage)
+		if err != nil {
+			return nil, nil, err
+		}
+		if given, ok := b.values["Hash"]; ok && given.Cmp(hash) != 0 {
+			return nil, nil, fmt.Errorf("Hash %s is not %s(PreImage) = %s", given, gadget.Name(), hash)
+		}
+		b.values["Hash"] = hash
+	}
+
+	seen := map[string]bool{}
+	var missing []string
+	var publicInputs []*big.Int
+	for i := 0; i < schema.NumField(); i++ {
+		field := schema.Field(i)
+		public := strings.Contains(field.Tag.Get("gnark"), "public")
+		seen[field.Name] = true
+
+		v, ok := b.values[field.Name]
+		if !ok {
+			missing = append(missing, field.Name)
+			continue
+		}
+		if explicit, ok := b.public[field.Name]; ok && explicit != public {
+			return nil, nil, fmt.Errorf("%s.%s is %s", circuitName, field.Name, visibility(public))
+		}
+		if v.Sign() < 0 || v.Cmp(b.curveID.ScalarField()) >= 0 {
+			return nil, nil, fmt.Errorf("%s is out of range for %s", field.Name, b.curveID)
+		}
+		if b.nonZero && !public && v.Sign() == 0 {
+			return nil, nil, fmt.Errorf("secret %s is zero", field.Name)
+		}
+
+		fields.Field(i).Set(reflect.ValueOf(frontend.Variable(v)))
+		if public {
+			publicInputs = append(publicInputs, v)
+		}
+	}
+
+	for name := range b.values {
+		if !seen[name] {
+			return nil, nil, fmt.Errorf("%s has no input %s", circuitName, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%s: missing inputs %s", circuitName, strings.Join(missing, ", "))
+	}
+
+	return circuit, publicInputs, nil
+}
+
+func visibility(public bool) string {
+	if public {
+		return "public"
+	}
+	return "secret"
+}
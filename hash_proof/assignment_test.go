@@ -0,0 +1,123 @@
+package hash_proof
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestNewAssignment(t *testing.T) {
+	circuit, public, err := NewAssignment("HashCircuit", WithSecret("PreImage", big.NewInt(35)), WithAutoHash())
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+
+	hash, err := MiMCHash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	if got := circuit.(*HashCircuit).Hash; got.(*big.Int).Cmp(hash) != 0 {
+		t.Fatalf("Auto hash %v does not match the native hash %s", got, hash)
+	}
+
+	w, err := frontend.NewWitness(circuit, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+	fromWitness, err := publicInputs(w)
+	if err != nil {
+		t.Fatalf("Failed to decode public witness: %v", err)
+	}
+	if !reflect.DeepEqual(public, fromWitness) {
+		t.Fatalf("Public inputs %v do not match the witness %v", public, fromWitness)
+	}
+}
+
+func TestNewAssignmentMatchesRotationHelper(t *testing.T) {
+	for _, secrets := range [][2]int64{{35, 36}, {1, 2}, {0, 1 << 40}} {
+		oldSecret, newSecret := big.NewInt(secrets[0]), big.NewInt(secrets[1])
+
+		helper, err := NewRotationAssignment(ecc.BN254, oldSecret, newSecret)
+		if err != nil {
+			t.Fatalf("Failed to build rotation assignment: %v", err)
+		}
+
+		built, public, err := NewAssignment("RotationCircuit",
+			WithSecret("OldSecret", oldSecret),
+			WithSecret("NewSecret", newSecret),
+			WithPublic("OldCommitment", helper.OldCommitment.(*big.Int)),
+			WithPublic("NewCommitment", helper.NewCommitment.(*big.Int)),
+			WithPublic("RotationNullifier", helper.RotationNullifier.(*big.Int)),
+		)
+		if err != nil {
+			t.Fatalf("Failed to build assignment: %v", err)
+		}
+		if !reflect.DeepEqual(built, helper) {
+			t.Fatalf("Builder %+v differs from helper %+v", built, helper)
+		}
+		if len(public) != 3 || public[2].Cmp(helper.RotationNullifier.(*big.Int)) != 0 {
+			t.Fatalf("Unexpected public inputs: %v", public)
+		}
+	}
+}
+
+func TestNewAssignmentErrors(t *testing.T) {
+	x := big.NewInt(35)
+	for _, tc := range []struct {
+		name    string
+		circuit string
+		opts    []AssignOption
+		want    string
+	}{
+		{"unknown circuit", "NoSuchCircuit", nil, "unknown circuit"},
+		{"missing inputs", "CommittedThresholdCircuit", []AssignOption{WithSecret("PreImage", x)}, "missing inputs Threshold, Hash, ThresholdHash"},
+		{"unknown input", "HashCircuit", []AssignOption{WithSecret("PreImage", x), WithAutoHash(), WithSecret("Salt", x)}, "no input Salt"},
+		{"wrong visibility", "HashCircuit", []AssignOption{WithPublic("PreImage", x), WithAutoHash()}, "PreImage is secret"},
+		{"duplicate", "HashCircuit", []AssignOption{WithSecret("PreImage", x), WithBytes("PreImage", []byte{35})}, "set twice"},
+		{"auto hash without Hash", "HashDeltaCircuit", []AssignOption{WithAutoHash()}, "no Hash input"},
+		{"inconsistent hash", "HashCircuit", []AssignOption{WithSecret("PreImage", x), WithPublic("Hash", x), WithAutoHash()}, "is not MiMC(PreImage)"},
+		{"out of range", "HashCircuit", []AssignOption{WithSecret("PreImage", ecc.BN254.ScalarField()), WithPublic("Hash", x)}, "out of range"},
+		{"zero secret", "HashCircuit", []AssignOption{WithSecret("PreImage", big.NewInt(0)), WithAutoHash(), WithNonZeroSecrets()}, "secret PreImage is zero"},
+	} {
+		_, _, err := NewAssignment(tc.circuit, tc.opts...)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestNewAssignmentZeroSecret(t *testing.T) {
+	zero := big.NewInt(0)
+	if _, _, err := NewAssignment("HashCircuit", WithSecret("PreImage", zero), WithAutoHash()); err != nil {
+		t.Fatalf("Zero secrets must be accepted without WithNonZeroSecrets: %v", err)
+	}
+
+	// Only secrets are checked; a public zero is legitimate.
+	_, _, err := NewAssignment("HashDeltaCircuit",
+		WithSecret("A", big.NewInt(35)), WithSecret("B", big.NewInt(35)), WithPublic("Delta", zero),
+		WithNonZeroSecrets())
+	if err != nil {
+		t.Fatalf("Public zero rejected: %v", err)
+	}
+}
+
+func TestCircuitAliases(t *testing.T) {
+	for alias, name := range circuitAliases {
+		if _, ok := assignableCircuits[alias]; ok {
+			t.Fatalf("Alias %s is also registered, so the circuit is listed twice", alias)
+		}
+		newCircuit, ok := lookupCircuit(alias)
+		if !ok || CircuitName(newCircuit()) != name {
+			t.Fatalf("Alias %s does not resolve to %s", alias, name)
+		}
+	}
+	for name, newCircuit := range assignableCircuits {
+		if CircuitName(newCircuit()) != name {
+			t.Fatalf("Circuit %s is registered as %s", CircuitName(newCircuit()), name)
+		}
+	}
+}
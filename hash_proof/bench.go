@@ -0,0 +1,110 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// BenchSchemaVersion is bumped whenever a field of the benchmark JSON is
+// renamed or changes meaning, so archived results are never diffed across
+// incompatible schemas.
+const BenchSchemaVersion = 1
+
+// BenchResult is the measured cost of proving and verifying one circuit.
+type BenchResult struct {
+	Circuit       string `json:"circuit"`
+	Curve         string `json:"curve"`
+	Hash          string `json:"hash"`
+	Constraints   int    `json:"constraints"`
+	ProveNsPerOp  int64  `json:"proveNsPerOp"`
+	VerifyNsPerOp int64  `json:"verifyNsPerOp"`
+	ProofBytes    int64  `json:"proofBytes"`
+}
+
+type benchReport struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Results       []BenchResult `json:"results"`
+}
+
+// ExportBenchmarkJSON writes results as an indented JSON document tagged with
+// BenchSchemaVersion.
+func ExportBenchmarkJSON(results []BenchResult, w io.Writer) error {
+	if results == nil {
+		results = []BenchResult{}
+	}
+	data, err := json.MarshalIndent(benchReport{SchemaVersion: BenchSchemaVersion, Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// RunBench compiles circuit, runs setup once and averages Prove and Verify
+// of assignment over iterations runs. The result's Hash is the circuit's
+// hash gadget, MiMC for circuits without one.
+func RunBench(curveID ecc.ID, circuit, assignment frontend.Circuit, iterations int) (BenchResult, error) {
+	if iterations < 1 {
+		return BenchResult{}, errors.New("iterations must be at least 1")
+	}
+
+	ccs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	witness, err := frontend.NewWitness(assignment, curveID.ScalarField())
+	if err != nil {
+		return BenchResult{}, err
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		return BenchResult{}, err
+	}
+
+	result := BenchResult{
+		Circuit:     CircuitName(circuit),
+		Curve:       curveID.String(),
+		Hash:        circuitGadget(circuit).Name(),
+		Constraints: ccs.GetNbConstraints(),
+	}
+
+	var proveTime, verifyTime time.Duration
+	for i := 0; i < iterations; i++ {
+		start := time.Now()
+		proof, err := groth16.Prove(ccs, pk, witness)
+		if err != nil {
+			return BenchResult{}, err
+		}
+		proveTime += time.Since(start)
+
+		start = time.Now()
+		if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+			return BenchResult{}, fmt.Errorf("benchmark proof does not verify: %w", err)
+		}
+		verifyTime += time.Since(start)
+
+		if i == 0 {
+			var buf bytes.Buffer
+			if result.ProofBytes, err = proof.WriteRawTo(&buf); err != nil {
+				return BenchResult{}, err
+			}
+		}
+	}
+	result.ProveNsPerOp = proveTime.Nanoseconds() / int64(iterations)
+	result.VerifyNsPerOp = verifyTime.Nanoseconds() / int64(iterations)
+
+	return result, nil
+}
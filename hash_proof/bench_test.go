@@ -0,0 +1,188 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+func TestExportBenchmarkJSON(t *testing.T) {
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+
+	result, err := RunBench(ecc.BN254, &HashCircuit{}, &HashCircuit{PreImage: 35, Hash: hash}, 2)
+	if err != nil {
+		t.Fatalf("Failed to run benchmark: %v", err)
+	}
+	if result.Circuit != "HashCircuit[MiMC]" || result.Curve != "bn254" || result.Hash != "MiMC" || result.Constraints == 0 || result.ProofBytes == 0 {
+		t.Fatalf("Unexpected benchmark result: %+v", result)
+	}
+
+	// The hash is the circuit's gadget.
+	poseidonHash, err := Poseidon2Hash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute Poseidon2 hash: %v", err)
+	}
+	poseidon, err := RunBench(ecc.BN254, &HashCircuitOf[PoseidonGadget]{}, &HashCircuitOf[PoseidonGadget]{PreImage: 35, Hash: poseidonHash}, 1)
+	if err != nil {
+		t.Fatalf("Failed to run Poseidon2 benchmark: %v", err)
+	}
+	if poseidon.Circuit != "HashCircuit[Poseidon2]" || poseidon.Hash != "Poseidon2" {
+		t.Fatalf("Unexpected Poseidon2 benchmark result: %+v", poseidon)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportBenchmarkJSON([]BenchResult{result}, &buf); err != nil {
+		t.Fatalf("Failed to export benchmark JSON: %v", err)
+	}
+
+	var report map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Benchmark export is not valid JSON: %v", err)
+	}
+	if report["schemaVersion"] != float64(BenchSchemaVersion) {
+		t.Fatalf("Unexpected schema version: %v", report["schemaVersion"])
+	}
+
+	results := report["results"].([]any)
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	fields := results[0].(map[string]any)
+
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	expected := []string{"circuit", "constraints", "curve", "hash", "proofBytes", "proveNsPerOp", "verifyNsPerOp"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Benchmark schema changed: got %v, expected %v", keys, expected)
+	}
+
+	for _, key := range []string{"constraints", "proveNsPerOp", "verifyNsPerOp", "proofBytes"} {
+		if v, ok := fields[key].(float64); !ok || v <= 0 {
+			t.Fatalf("%s is not a positive number: %v", key, fields[key])
+		}
+	}
+}
+
+// BenchmarkGroth16VsPlonk measures setup, prove and verify of HashCircuit
+// under both backends. PLONK's setup is universal but its proofs are larger;
+// the KZG SRS it needs is generated before any timer starts.
+func BenchmarkGroth16VsPlonk(b *testing.B) {
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	witness, err := frontend.NewWitness(&HashCircuit{PreImage: 35, Hash: hash}, ecc.BN254.ScalarField())
+	if err != nil {
+		b.Fatalf("Failed to create witness: %v", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		b.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	size := func(b *testing.B, w io.WriterTo) float64 {
+		var buf bytes.Buffer
+		if _, err := w.WriteTo(&buf); err != nil {
+			b.Fatalf("Failed to serialize: %v", err)
+		}
+		return float64(buf.Len())
+	}
+
+	b.Run("groth16", func(b *testing.B) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &HashCircuit{})
+		if err != nil {
+			b.Fatalf("Failed to compile circuit: %v", err)
+		}
+		pk, vk, err := groth16.Setup(ccs)
+		if err != nil {
+			b.Fatalf("Failed to setup: %v", err)
+		}
+		proof, err := groth16.Prove(ccs, pk, witness)
+		if err != nil {
+			b.Fatalf("Failed to prove: %v", err)
+		}
+
+		b.Run("setup", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := groth16.Setup(ccs); err != nil {
+					b.Fatalf("Failed to setup: %v", err)
+				}
+			}
+			b.ReportMetric(size(b, pk), "pk-bytes")
+			b.ReportMetric(size(b, vk), "vk-bytes")
+		})
+		b.Run("prove", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := groth16.Prove(ccs, pk, witness); err != nil {
+					b.Fatalf("Failed to prove: %v", err)
+				}
+			}
+			b.ReportMetric(float64(ccs.GetNbConstraints()), "constraints")
+			b.ReportMetric(size(b, proof), "proof-bytes")
+		})
+		b.Run("verify", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+					b.Fatalf("Failed to verify: %v", err)
+				}
+			}
+		})
+	})
+
+	b.Run("plonk", func(b *testing.B) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &HashCircuit{})
+		if err != nil {
+			b.Fatalf("Failed to compile circuit: %v", err)
+		}
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		if err != nil {
+			b.Fatalf("Failed to generate SRS: %v", err)
+		}
+		pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+		if err != nil {
+			b.Fatalf("Failed to setup: %v", err)
+		}
+		proof, err := plonk.Prove(ccs, pk, witness)
+		if err != nil {
+			b.Fatalf("Failed to prove: %v", err)
+		}
+
+		b.Run("setup", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := plonk.Setup(ccs, srs, srsLagrange); err != nil {
+					b.Fatalf("Failed to setup: %v", err)
+				}
+			}
+			b.ReportMetric(size(b, pk), "pk-bytes")
+			b.ReportMetric(size(b, vk), "vk-bytes")
+		})
+		b.Run("prove", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := plonk.Prove(ccs, pk, witness); err != nil {
+					b.Fatalf("Failed to prove: %v", err)
+				}
+			}
+			b.ReportMetric(float64(ccs.GetNbConstraints()), "constraints")
+			b.ReportMetric(size(b, proof), "proof-bytes")
+		})
+		b.Run("verify", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := plonk.Verify(proof, vk, publicWitness); err != nil {
+					b.Fatalf("Failed to verify: %v", err)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// BitCommitmentBits is the width of the secret and of the salt of
+// BitCommitmentCircuit.
+const BitCommitmentBits = 128
+
+// BitCommitmentCircuit proves knowledge of the preimage of Hash and that
+// Commitment is SHA-256 of its bits followed by those of a secret salt. The
+// digest is split into two 128-bit halves, high first, so that it fits every
+// supported scalar field.
+//
+// SHA-256 is computed bit by bit, so it yields the same value whatever the
+// curve, unlike Hash, which is MiMC over the scalar field. Two proofs on
+// different curves are linked off-chain by checking that their Commitment
+// inputs are equal: both then open the same secret, without revealing it,
+// while each Hash stays native to its own curve.
+type BitCommitmentCircuit struct {
+	PreImage   frontend.Variable    `gnark:",secret"`
+	Salt       frontend.Variable    `gnark:",secret"`
+	Hash       frontend.Variable    `gnark:",public"`
+	Commitment [2]frontend.Variable `gnark:",public"`
+}
+
+func (circuit *BitCommitmentCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	bf, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+
+	// Big-endian bytes of the secret then the salt. ToBinary also constrains
+	// both to BitCommitmentBits bits.
+	var message []uints.U8
+	for _, v := range []frontend.Variable{circuit.PreImage, circuit.Salt} {
+		bits := api.ToBinary(v, BitCommitmentBits)
+		for i := len(bits) - 8; i >= 0; i -= 8 {
+			message = append(message, bf.ByteValueOf(api.FromBinary(bits[i:i+8]...)))
+		}
+	}
+
+	sha, err := sha2.New(api)
+	if err != nil {
+		return err
+	}
+	sha.Write(message)
+	digest := sha.Sum()
+
+	for half := range circuit.Commitment {
+		var acc frontend.Variable = 0
+		for _, b := range digest[half*16 : (half+1)*16] {
+			acc = api.Add(api.Mul(acc, 256), bf.Value(b))
+		}
+		api.AssertIsEqual(circuit.Commitment[half], acc)
+	}
+
+	return nil
+}
+
+// BitCommitment computes the Commitment of BitCommitmentCircuit for secret
+// and salt. It is the same on every curve.
+func BitCommitment(secret, salt *big.Int) ([2]*big.Int, error) {
+	var message [2 * BitCommitmentBits / 8]byte
+	for i, v := range []*big.Int{secret, salt} {
+		if v.Sign() < 0 || v.BitLen() > BitCommitmentBits {
+			return [2]*big.Int{}, fmt.Errorf("%s does not fit in %d bits", v, BitCommitmentBits)
+		}
+		v.FillBytes(message[i*BitCommitmentBits/8 : (i+1)*BitCommitmentBits/8])
+	}
+	digest := sha256.Sum256(message[:])
+	return [2]*big.Int{new(big.Int).SetBytes(digest[:16]), new(big.Int).SetBytes(digest[16:])}, nil
+}
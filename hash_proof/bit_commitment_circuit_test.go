@@ -0,0 +1,83 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestBitCommitment(t *testing.T) {
+	salt := big.NewInt(0xC0FFEE)
+
+	c, err := BitCommitment(big.NewInt(35), salt)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	again, err := BitCommitment(big.NewInt(35), salt)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if c[0].Cmp(again[0]) != 0 || c[1].Cmp(again[1]) != 0 {
+		t.Fatal("Commitment is not stable")
+	}
+
+	other, err := BitCommitment(big.NewInt(36), salt)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if c[0].Cmp(other[0]) == 0 && c[1].Cmp(other[1]) == 0 {
+		t.Fatal("A changed secret kept the same commitment")
+	}
+
+	if _, err := BitCommitment(new(big.Int).Lsh(big.NewInt(1), BitCommitmentBits), salt); err == nil {
+		t.Fatal("Expected a secret wider than BitCommitmentBits to be rejected")
+	}
+}
+
+func TestBitCommitmentCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit BitCommitmentCircuit
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	t.Logf("BitCommitmentCircuit constraints: %d", ccs.GetNbConstraints())
+
+	preImage, salt := big.NewInt(35), big.NewInt(0xC0FFEE)
+	commitment, err := BitCommitment(preImage, salt)
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	assignment := func(curveID ecc.ID, salt *big.Int) *BitCommitmentCircuit {
+		hash, err := MiMCHash(curveID, preImage)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+		return &BitCommitmentCircuit{
+			PreImage:   preImage,
+			Salt:       salt,
+			Hash:       hash,
+			Commitment: [2]frontend.Variable{commitment[0], commitment[1]},
+		}
+	}
+
+	// SHA-256 makes the circuit large, so only Groth16 is exercised.
+	assert.CheckCircuit(&circuit,
+		test.WithValidAssignment(assignment(ecc.BN254, salt)),
+		test.WithInvalidAssignment(assignment(ecc.BN254, big.NewInt(0xBEEF))),
+		test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16))
+
+	// The same commitment satisfies the circuit over another curve, where
+	// Hash differs.
+	if err := test.IsSolved(&circuit, assignment(ecc.BLS12_381, salt), ecc.BLS12_381.ScalarField()); err != nil {
+		t.Fatalf("Commitment does not carry over to BLS12-381: %v", err)
+	}
+}
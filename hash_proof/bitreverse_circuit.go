@@ -0,0 +1,56 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// BitReverseHashCircuit proves knowledge of a Width-bit secret such that Hash
+// is the MiMC hash of the secret with its Width bits in reverse order.
+type BitReverseHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+
+	Width int `gnark:"-"`
+}
+
+// NewBitReverseHashCircuit returns a circuit definition for n-bit secrets.
+func NewBitReverseHashCircuit(n int) *BitReverseHashCircuit {
+	return &BitReverseHashCircuit{Width: n}
+}
+
+func (circuit *BitReverseHashCircuit) Define(api frontend.API) error {
+	// Both the secret and its reversal must fit the field without wrapping.
+	if circuit.Width < 1 || circuit.Width >= api.Compiler().FieldBitLen() {
+		return fmt.Errorf("width must be between 1 and %d, got %d", api.Compiler().FieldBitLen()-1, circuit.Width)
+	}
+
+	// ToBinary also constrains the secret to Width bits.
+	bits := api.ToBinary(circuit.PreImage, circuit.Width)
+	for i, j := 0, len(bits)-1; i < j; i, j = i+1, j-1 {
+		bits[i], bits[j] = bits[j], bits[i]
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(api.FromBinary(bits...))
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	return nil
+}
+
+// ReverseBits returns x with its n low bits in reverse order. Bits of x above
+// n are dropped.
+func ReverseBits(x *big.Int, n int) *big.Int {
+	r := new(big.Int)
+	for i := 0; i < n; i++ {
+		r.SetBit(r, n-1-i, x.Bit(i))
+	}
+	return r
+}
@@ -0,0 +1,51 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestReverseBits(t *testing.T) {
+	if r := ReverseBits(big.NewInt(0b1101), 8); r.Int64() != 0b10110000 {
+		t.Fatalf("Unexpected reversal: %b", r)
+	}
+	if r := ReverseBits(big.NewInt(0b1101), 4); r.Int64() != 0b1011 {
+		t.Fatalf("Unexpected reversal: %b", r)
+	}
+	x := big.NewInt(0xC0FFEE)
+	if ReverseBits(ReverseBits(x, 32), 32).Cmp(x) != 0 {
+		t.Fatal("Reversal is not an involution")
+	}
+}
+
+func TestBitReverseHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	circuit := NewBitReverseHashCircuit(8)
+
+	preImage := big.NewInt(35)
+	hash, err := MiMCHash(ecc.BN254, ReverseBits(preImage, 8))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	assert.ProverSucceeded(circuit, &BitReverseHashCircuit{
+		PreImage: preImage,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+
+	// The hash of the unreversed secret does not match.
+	assert.ProverFailed(circuit, &BitReverseHashCircuit{
+		PreImage: preImage,
+		Hash:     "2474112249751028531650252582366798049474486386634137916759752348728204118534",
+	}, test.WithCurves(ecc.BN254))
+
+	// 35 + 256 has the same low 8 bits but does not fit the width.
+	assert.ProverFailed(circuit, &BitReverseHashCircuit{
+		PreImage: 35 + 256,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,77 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/selector"
+)
+
+const (
+	// BloomBits is the size of the public Bloom filter bit array.
+	BloomBits = 64
+	// BloomHashes is the number of salted hashes an element is inserted with.
+	BloomHashes = 3
+)
+
+var bloomPositionSchema = []string{"salt", "secret"}
+
+// BloomNonMembershipCircuit proves that the secret committed to by Hash is
+// definitely absent from the public Bloom filter: at least one of the
+// positions, the canonical hash of salt and secret mod BloomBits, is not set
+// in Filter.
+type BloomNonMembershipCircuit struct {
+	PreImage frontend.Variable              `gnark:",secret"`
+	Hash     frontend.Variable              `gnark:",public"`
+	Salts    [BloomHashes]frontend.Variable `gnark:",public"`
+	Filter   [BloomBits]frontend.Variable   `gnark:",public"`
+}
+
+func (circuit *BloomNonMembershipCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	for _, bit := range circuit.Filter {
+		api.AssertIsBoolean(bit)
+	}
+
+	allSet := frontend.Variable(1)
+	for _, salt := range circuit.Salts {
+		h, err := canonicalHash(api, salt, circuit.PreImage)
+		if err != nil {
+			return err
+		}
+		pos, err := modConstant(api, h, BloomBits)
+		if err != nil {
+			return err
+		}
+		allSet = api.Mul(allSet, selector.Mux(api, pos, circuit.Filter[:]...))
+	}
+	api.AssertIsEqual(allSet, 0)
+
+	return nil
+}
+
+// BloomPositions returns the filter positions secret is inserted at, one per
+// salt.
+func BloomPositions(curveID ecc.ID, secret *big.Int, salts [BloomHashes]*big.Int) ([BloomHashes]int, error) {
+	var positions [BloomHashes]int
+	for i, salt := range salts {
+		h, err := CanonicalHash(curveID, map[string]*big.Int{
+			"salt":   salt,
+			"secret": secret,
+		}, bloomPositionSchema)
+		if err != nil {
+			return positions, err
+		}
+		positions[i] = int(new(big.Int).Mod(h, big.NewInt(BloomBits)).Int64())
+	}
+	return positions, nil
+}
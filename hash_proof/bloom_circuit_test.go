@@ -0,0 +1,60 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func TestBloomNonMembershipCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	salts := [BloomHashes]*big.Int{big.NewInt(11), big.NewInt(22), big.NewInt(33)}
+
+	var filter [BloomBits]int
+	for _, member := range []int64{1, 2, 3} {
+		positions, err := BloomPositions(ecc.BN254, big.NewInt(member), salts)
+		if err != nil {
+			t.Fatalf("Failed to compute Bloom positions: %v", err)
+		}
+		for _, p := range positions {
+			filter[p] = 1
+		}
+	}
+
+	assignment := func(secret int64) *BloomNonMembershipCircuit {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to hash secret: %v", err)
+		}
+		a := &BloomNonMembershipCircuit{PreImage: secret, Hash: hash}
+		for i, salt := range salts {
+			a.Salts[i] = salt
+		}
+		for i, bit := range filter {
+			a.Filter[i] = bit
+		}
+		return a
+	}
+
+	absent := int64(35)
+	positions, err := BloomPositions(ecc.BN254, big.NewInt(absent), salts)
+	if err != nil {
+		t.Fatalf("Failed to compute Bloom positions: %v", err)
+	}
+	if filter[positions[0]] == 1 && filter[positions[1]] == 1 && filter[positions[2]] == 1 {
+		t.Fatalf("Test element %d is a false positive, pick another", absent)
+	}
+
+	var circuit BloomNonMembershipCircuit
+	assert.ProverSucceeded(&circuit, assignment(absent), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(2), test.WithCurves(ecc.BN254))
+
+	// Filter bits must be boolean, otherwise a prover could zero the product.
+	notBoolean := assignment(absent)
+	notBoolean.Filter[0] = frontend.Variable(2)
+	assert.ProverFailed(&circuit, notBoolean, test.WithCurves(ecc.BN254))
+}
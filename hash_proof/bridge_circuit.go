@@ -0,0 +1,101 @@
+package hash_proof
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+var bridgeCommitmentSchema = []string{"value", "salt"}
+
+// BridgeCircuit proves that the public MiMC and Poseidon2 commitments open
+// to the same secret Value, each with its own salt, so a MiMC commitment can
+// be migrated to Poseidon2 without revealing what it commits to. The MiMC
+// commitment is the first public input, the Poseidon2 one the second.
+type BridgeCircuit struct {
+	Value              frontend.Variable `gnark:",secret"`
+	MiMCSalt           frontend.Variable `gnark:",secret"`
+	PoseidonSalt       frontend.Variable `gnark:",secret"`
+	MiMCCommitment     frontend.Variable `gnark:",public"`
+	PoseidonCommitment frontend.Variable `gnark:",public"`
+}
+
+func (circuit *BridgeCircuit) Define(api frontend.API) error {
+	for _, c := range []struct {
+		gadget     HashGadget
+		salt       frontend.Variable
+		commitment frontend.Variable
+	}{
+		{MiMCGadget{}, circuit.MiMCSalt, circuit.MiMCCommitment},
+		{PoseidonGadget{}, circuit.PoseidonSalt, circuit.PoseidonCommitment},
+	} {
+		commitment, err := canonicalGadgetHash(api, c.gadget, circuit.Value, c.salt)
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(c.commitment, commitment)
+	}
+	return nil
+}
+
+// BatchBridgeCircuit proves several bridges in one proof for bulk migration.
+// The number of bridges is fixed by NewBatchBridgeCircuit; public inputs are
+// the commitment pairs in order.
+type BatchBridgeCircuit struct {
+	Bridges []BridgeCircuit
+}
+
+// NewBatchBridgeCircuit returns a circuit definition for k bridges.
+func NewBatchBridgeCircuit(k int) *BatchBridgeCircuit {
+	return &BatchBridgeCircuit{Bridges: make([]BridgeCircuit, k)}
+}
+
+func (circuit *BatchBridgeCircuit) Define(api frontend.API) error {
+	if len(circuit.Bridges) == 0 {
+		return errors.New("batch bridge circuit must be created with NewBatchBridgeCircuit")
+	}
+	for i := range circuit.Bridges {
+		if err := circuit.Bridges[i].Define(api); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BridgeCommitments computes the MiMC and Poseidon2 commitments of value that
+// BridgeCircuit links, each the canonical hash of value and its salt.
+func BridgeCommitments(curveID ecc.ID, value, mimcSalt, poseidonSalt *big.Int) (mimcCommitment, poseidonCommitment *big.Int, err error) {
+	mimcCommitment, err = CanonicalGadgetHash(MiMCGadget{}, curveID, map[string]*big.Int{
+		"value": value,
+		"salt":  mimcSalt,
+	}, bridgeCommitmentSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	poseidonCommitment, err = CanonicalGadgetHash(PoseidonGadget{}, curveID, map[string]*big.Int{
+		"value": value,
+		"salt":  poseidonSalt,
+	}, bridgeCommitmentSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mimcCommitment, poseidonCommitment, nil
+}
+
+// NewBridgeAssignment returns the BridgeCircuit assignment for value and its
+// salts.
+func NewBridgeAssignment(curveID ecc.ID, value, mimcSalt, poseidonSalt *big.Int) (*BridgeCircuit, error) {
+	m, p, err := BridgeCommitments(curveID, value, mimcSalt, poseidonSalt)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeCircuit{
+		Value:              value,
+		MiMCSalt:           mimcSalt,
+		PoseidonSalt:       poseidonSalt,
+		MiMCCommitment:     m,
+		PoseidonCommitment: p,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package hash_proof
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+const (
+	bridgeMiMCCommitment     = "10947236931945469513256794082485247964823335254069361037233473432407057806031"
+	bridgePoseidonCommitment = "16995577581759042126541531923758489473356360607380989518185374309872197455184"
+	// bridgeCalldataInputs are the public-input words of verifyProof calldata
+	// for the bridge of 35 with salts 1 and 2.
+	bridgeCalldataInputs = "1833eaa19c2bfed7590514fe51e77b47fa5c44ad75187cd4c5064dd4a7fab2cf" +
+		"259327f101e8d3a34d7aac02ca27e3933c14610bf513d1aad132f0ba04444d50"
+)
+
+func TestBridgeCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit BridgeCircuit
+
+	valid, err := NewBridgeAssignment(ecc.BN254, big.NewInt(35), big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+	assert.ProverSucceeded(&circuit, valid, test.WithCurves(ecc.BN254))
+
+	// The Poseidon2 commitment of another secret must not bridge.
+	other, err := NewBridgeAssignment(ecc.BN254, big.NewInt(36), big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+	mismatched := *valid
+	mismatched.PoseidonCommitment = other.PoseidonCommitment
+	assert.ProverFailed(&circuit, &mismatched, test.WithCurves(ecc.BN254))
+}
+
+func TestBatchBridgeCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const k = 16
+	assignment := NewBatchBridgeCircuit(k)
+	for i := range assignment.Bridges {
+		bridge, err := NewBridgeAssignment(ecc.BN254, big.NewInt(int64(100+i)), big.NewInt(int64(2*i)), big.NewInt(int64(2*i+1)))
+		if err != nil {
+			t.Fatalf("Failed to build assignment: %v", err)
+		}
+		assignment.Bridges[i] = *bridge
+	}
+
+	assert.ProverSucceeded(NewBatchBridgeCircuit(k), assignment, test.WithCurves(ecc.BN254))
+
+	assignment.Bridges[7].Value = 1000
+	assert.ProverFailed(NewBatchBridgeCircuit(k), assignment, test.WithCurves(ecc.BN254))
+}
+
+func TestBridgePublicInputOrder(t *testing.T) {
+	assignment, err := NewBridgeAssignment(ecc.BN254, big.NewInt(35), big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+
+	pub, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+	described, err := DescribePublicWitness(&BridgeCircuit{}, pub, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to describe public witness: %v", err)
+	}
+	expected := map[string]string{"MiMCCommitment": bridgeMiMCCommitment, "PoseidonCommitment": bridgePoseidonCommitment}
+	if !reflect.DeepEqual(described, expected) {
+		t.Fatalf("Unexpected public inputs: got %v, want %v", described, expected)
+	}
+
+	var circuit BridgeCircuit
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	pk, _, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("Failed to create witness: %v", err)
+	}
+	proof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		t.Fatalf("Failed to create proof: %v", err)
+	}
+
+	inputs, err := publicInputs(pub)
+	if err != nil {
+		t.Fatalf("Failed to decode public witness: %v", err)
+	}
+	data, err := EncodeVerifyCalldata(proof, inputs)
+	if err != nil {
+		t.Fatalf("Failed to encode calldata: %v", err)
+	}
+	if got := hex.EncodeToString(data[4+8*32:]); got != bridgeCalldataInputs {
+		t.Fatalf("Unexpected calldata inputs:\ngot  %s\nwant %s", got, bridgeCalldataInputs)
+	}
+}
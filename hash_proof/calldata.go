@@ -0,0 +1,192 @@
+package hash_proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// proofLabels names the eight words of an EIP-197 encoded proof, in the order
+// the exported verifier's verifyProof expects them. G2 coordinates are given
+// imaginary part first.
+var proofLabels = [8]string{"A.X", "A.Y", "B.X.A1", "B.X.A0", "B.Y.A1", "B.Y.A0", "C.X", "C.Y"}
+
+// NamedInput is a public input of a decoded verifyProof call.
+type NamedInput struct {
+	Name  string
+	Value *big.Int
+}
+
+// DecodedCall is a verifyProof call unpacked from its ABI encoding. Problems
+// lists every element that cannot be valid, such as an off-curve point or an
+// unreduced public input.
+type DecodedCall struct {
+	Proof    [8]*big.Int
+	Inputs   []NamedInput
+	Problems []string
+}
+
+// verifyProofSelector returns the selector of verifyProof in VerifierABI.
+func verifyProofSelector(nbInputs int) []byte {
+	return verifierFunction(nbInputs, "verifyProof").Selector()
+}
+
+// EncodeVerifyCalldata ABI-encodes a call to the exported verifier's
+// verifyProof for a BN254 proof without commitments.
+func EncodeVerifyCalldata(proof groth16.Proof, publicInputs []*big.Int) ([]byte, error) {
+	p, ok := proof.(interface{ MarshalSolidity() []byte })
+	if !ok || proof.CurveID() != ecc.BN254 {
+		return nil, fmt.Errorf("verifyProof calldata is only defined for BN254 proofs, got %s", proof.CurveID())
+	}
+	words := p.MarshalSolidity()
+	if len(words) != 8*32 {
+		return nil, errors.New("proofs with commitments are not supported")
+	}
+
+	data := append(verifyProofSelector(len(publicInputs)), words...)
+	word := make([]byte, 32)
+	for _, in := range publicInputs {
+		if in.Sign() < 0 || in.BitLen() > 256 {
+			return nil, fmt.Errorf("public input %s does not fit in a uint256", in)
+		}
+		data = append(data, in.FillBytes(word)...)
+	}
+	return data, nil
+}
+
+// DecodeVerifyCalldata parses ABI-encoded verifyProof arguments, with or
+// without the leading selector, and checks every proof point and public
+// input. Inputs are labeled with inputNames in order, or by index when no
+// name is given.
+func DecodeVerifyCalldata(data []byte, inputNames ...string) (*DecodedCall, error) {
+	if len(data)%32 == 4 {
+		nbInputs := (len(data) - 4 - 8*32) / 32
+		if nbInputs < 0 {
+			return nil, fmt.Errorf("calldata too short: %d bytes", len(data))
+		}
+		if got, want := data[:4], verifyProofSelector(nbInputs); string(got) != string(want) {
+			return nil, fmt.Errorf("selector 0x%x is not verifyProof(uint256[8],uint256[%d]) (0x%x)", got, nbInputs, want)
+		}
+		data = data[4:]
+	}
+	if len(data)%32 != 0 || len(data) < 8*32 {
+		return nil, fmt.Errorf("calldata is not a proof followed by public inputs: %d bytes", len(data))
+	}
+
+	call := &DecodedCall{}
+	for i := range call.Proof {
+		call.Proof[i] = new(big.Int).SetBytes(data[i*32 : (i+1)*32])
+	}
+	for i := 8; i < len(data)/32; i++ {
+		name := fmt.Sprintf("input[%d]", i-8)
+		if i-8 < len(inputNames) {
+			name = inputNames[i-8]
+		}
+		call.Inputs = append(call.Inputs, NamedInput{
+			Name:  name,
+			Value: new(big.Int).SetBytes(data[i*32 : (i+1)*32]),
+		})
+	}
+
+	call.check()
+	return call, nil
+}
+
+func (call *DecodedCall) check() {
+	p := ecc.BN254.BaseField()
+	r := ecc.BN254.ScalarField()
+
+	inField := true
+	for i, v := range call.Proof {
+		if v.Cmp(p) >= 0 {
+			call.Problems = append(call.Problems, fmt.Sprintf("%s is not in the base field", proofLabels[i]))
+			inField = false
+		}
+	}
+	for _, in := range call.Inputs {
+		if in.Value.Cmp(r) >= 0 {
+			call.Problems = append(call.Problems, fmt.Sprintf("%s is not reduced modulo the scalar field", in.Name))
+		}
+	}
+	if !inField {
+		return
+	}
+
+	w := call.Proof
+	if !g1OnCurve(w[0], w[1]) {
+		call.Problems = append(call.Problems, "A is not on the curve")
+	}
+	if !g2OnCurve(w[2], w[3], w[4], w[5]) {
+		if g2OnCurve(w[3], w[2], w[5], w[4]) {
+			call.Problems = append(call.Problems, "B is not on the curve, its coordinates are in (A0, A1) order instead of (A1, A0)")
+		} else {
+			call.Problems = append(call.Problems, "B is not on the curve")
+		}
+	}
+	if !g1OnCurve(w[6], w[7]) {
+		call.Problems = append(call.Problems, "C is not on the curve")
+	}
+}
+
+func g1OnCurve(x, y *big.Int) bool {
+	var pt bn254.G1Affine
+	pt.X.SetBigInt(x)
+	pt.Y.SetBigInt(y)
+	return pt.IsOnCurve()
+}
+
+func g2OnCurve(xA1, xA0, yA1, yA0 *big.Int) bool {
+	var pt bn254.G2Affine
+	pt.X.A1.SetBigInt(xA1)
+	pt.X.A0.SetBigInt(xA0)
+	pt.Y.A1.SetBigInt(yA1)
+	pt.Y.A0.SetBigInt(yA0)
+	return pt.IsOnCurve()
+}
+
+// Diff names every element of call that differs from expected, e.g. the
+// decoded local proof a failed on-chain call was meant to carry.
+func (call *DecodedCall) Diff(expected *DecodedCall) []string {
+	var diffs []string
+	for i := range call.Proof {
+		if call.Proof[i].Cmp(expected.Proof[i]) != 0 {
+			diffs = append(diffs, fmt.Sprintf("%s: %s, expected %s", proofLabels[i], call.Proof[i], expected.Proof[i]))
+		}
+	}
+	if len(call.Inputs) != len(expected.Inputs) {
+		return append(diffs, fmt.Sprintf("%d public inputs, expected %d", len(call.Inputs), len(expected.Inputs)))
+	}
+	for i, in := range call.Inputs {
+		if in.Value.Cmp(expected.Inputs[i].Value) != 0 {
+			diffs = append(diffs, fmt.Sprintf("%s: %s, expected %s", in.Name, in.Value, expected.Inputs[i].Value))
+		}
+	}
+	return diffs
+}
+
+// String pretty-prints the call, one labeled element per line.
+func (call *DecodedCall) String() string {
+	var b strings.Builder
+	b.WriteString("proof:\n")
+	for i, v := range call.Proof {
+		fmt.Fprintf(&b, "  %-7s %s\n", proofLabels[i], v)
+	}
+	b.WriteString("inputs:\n")
+	for _, in := range call.Inputs {
+		fmt.Fprintf(&b, "  %s: %s\n", in.Name, in.Value)
+	}
+	if len(call.Problems) == 0 {
+		b.WriteString("all elements are well-formed")
+	} else {
+		b.WriteString("problems:")
+		for _, p := range call.Problems {
+			fmt.Fprintf(&b, "\n  - %s", p)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package hash_proof_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestDecodeVerifyCalldata(t *testing.T) {
+	_, _, _, proof, _ := testutil.GenerateFixture(t, ecc.BN254)
+	hash, _ := new(big.Int).SetString("2474112249751028531650252582366798049474486386634137916759752348728204118534", 10)
+
+	data, err := hash_proof.EncodeVerifyCalldata(proof, []*big.Int{hash})
+	if err != nil {
+		t.Fatalf("Failed to encode calldata: %v", err)
+	}
+
+	expected, err := hash_proof.DecodeVerifyCalldata(data, "Hash")
+	if err != nil {
+		t.Fatalf("Failed to decode calldata: %v", err)
+	}
+	if len(expected.Problems) != 0 {
+		t.Fatalf("Unexpected problems in a valid call: %v", expected.Problems)
+	}
+	if len(expected.Inputs) != 1 || expected.Inputs[0].Name != "Hash" || expected.Inputs[0].Value.Cmp(hash) != 0 {
+		t.Fatalf("Unexpected public inputs: %v", expected.Inputs)
+	}
+
+	reencoded := append([]byte{}, data[:4]...)
+	for _, v := range expected.Proof {
+		reencoded = append(reencoded, v.FillBytes(make([]byte, 32))...)
+	}
+	reencoded = append(reencoded, hash.FillBytes(make([]byte, 32))...)
+	if string(reencoded) != string(data) {
+		t.Fatal("Decoded call does not re-encode to the original calldata")
+	}
+
+	word := func(data []byte, i int) []byte { return data[4+i*32 : 4+(i+1)*32] }
+
+	t.Run("swapped B coordinates", func(t *testing.T) {
+		swapped := append([]byte{}, data...)
+		for _, i := range []int{2, 4} {
+			a, b := append([]byte{}, word(swapped, i)...), append([]byte{}, word(swapped, i+1)...)
+			copy(word(swapped, i), b)
+			copy(word(swapped, i+1), a)
+		}
+		call, err := hash_proof.DecodeVerifyCalldata(swapped, "Hash")
+		if err != nil {
+			t.Fatalf("Failed to decode calldata: %v", err)
+		}
+		if len(call.Problems) != 1 || !strings.Contains(call.Problems[0], "(A0, A1) order") {
+			t.Fatalf("Swapped B coordinates not detected: %v", call.Problems)
+		}
+	})
+
+	t.Run("off-curve point", func(t *testing.T) {
+		offCurve := append([]byte{}, data...)
+		word(offCurve, 7)[31] ^= 1
+		call, err := hash_proof.DecodeVerifyCalldata(offCurve, "Hash")
+		if err != nil {
+			t.Fatalf("Failed to decode calldata: %v", err)
+		}
+		if len(call.Problems) != 1 || call.Problems[0] != "C is not on the curve" {
+			t.Fatalf("Off-curve C not detected: %v", call.Problems)
+		}
+	})
+
+	t.Run("diff against local proof", func(t *testing.T) {
+		altered := append([]byte{}, data...)
+		word(altered, 8)[31] ^= 1
+		call, err := hash_proof.DecodeVerifyCalldata(altered, "Hash")
+		if err != nil {
+			t.Fatalf("Failed to decode calldata: %v", err)
+		}
+		if diffs := call.Diff(expected); len(diffs) != 1 || !strings.HasPrefix(diffs[0], "Hash:") {
+			t.Fatalf("Diff does not name the altered input: %v", diffs)
+		}
+	})
+
+	if _, err := hash_proof.DecodeVerifyCalldata(append([]byte{0, 0, 0, 0}, data[4:]...)); err == nil {
+		t.Fatal("Expected a wrong selector to be rejected")
+	}
+}
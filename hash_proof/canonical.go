@@ -0,0 +1,163 @@
+package hash_proof
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"text/template"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// CanonicalAbsorb orders the named elements as declared by schema and prefixes
+// them with their count. Every multi-input hash in the package absorbs this
+// sequence, so callers that agree on the schema agree on the digest. Each
+// element occupies exactly one field element, so no padding is needed.
+func CanonicalAbsorb(elems map[string]*big.Int, schema []string) ([]*big.Int, error) {
+	if len(elems) != len(schema) {
+		return nil, fmt.Errorf("schema declares %d elements, got %d", len(schema), len(elems))
+	}
+
+	out := make([]*big.Int, 0, len(schema)+1)
+	out = append(out, big.NewInt(int64(len(schema))))
+	seen := make(map[string]bool, len(schema))
+	for _, name := range schema {
+		if seen[name] {
+			return nil, fmt.Errorf("schema declares %q twice", name)
+		}
+		seen[name] = true
+
+		v, ok := elems[name]
+		if !ok || v == nil {
+			return nil, fmt.Errorf("missing element %q", name)
+		}
+		if v.Sign() < 0 {
+			return nil, fmt.Errorf("element %q is negative", name)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// CanonicalHash is the MiMC digest of the canonical absorption of elems.
+func CanonicalHash(curveID ecc.ID, elems map[string]*big.Int, schema []string) (*big.Int, error) {
+	return CanonicalGadgetHash(MiMCGadget{}, curveID, elems, schema)
+}
+
+// CanonicalGadgetHash is the digest gadget computes over the canonical
+// absorption of elems.
+func CanonicalGadgetHash(gadget HashGadget, curveID ecc.ID, elems map[string]*big.Int, schema []string) (*big.Int, error) {
+	absorbed, err := CanonicalAbsorb(elems, schema)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range absorbed[1:] {
+		if v.Cmp(curveID.ScalarField()) >= 0 {
+			return nil, fmt.Errorf("element %q exceeds the %s scalar field", schema[i], curveID)
+		}
+	}
+	return GadgetHash(gadget, curveID, absorbed...)
+}
+
+// CanonicalPacking returns the bytes a contract produces with
+// abi.encodePacked over the canonical absorption: 32-byte big-endian words.
+func CanonicalPacking(absorbed []*big.Int) []byte {
+	out := make([]byte, 32*len(absorbed))
+	for i, v := range absorbed {
+		v.FillBytes(out[32*i : 32*(i+1)])
+	}
+	return out
+}
+
+// canonicalHash is the in-circuit counterpart of CanonicalHash; elems must be
+// given in schema order.
+func canonicalHash(api frontend.API, elems ...frontend.Variable) (frontend.Variable, error) {
+	return canonicalGadgetHash(api, MiMCGadget{}, elems...)
+}
+
+// canonicalGadgetHash is the in-circuit counterpart of CanonicalGadgetHash.
+func canonicalGadgetHash(api frontend.API, gadget HashGadget, elems ...frontend.Variable) (frontend.Variable, error) {
+	hFunc, err := gadget.New(api)
+	if err != nil {
+		return nil, err
+	}
+	hFunc.Write(len(elems))
+	hFunc.Write(elems...)
+	return hFunc.Sum(), nil
+}
+
+var canonicalSolidityTemplate = template.Must(template.New("canonical").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`// SPDX-License-Identifier: MIT
+// Code generated by hash_proof. DO NOT EDIT.
+pragma solidity ^0.8.0;
+
+/// @notice Canonical absorption order for {{.Name}}. MiMC itself is not
+/// computed on-chain; contracts only need the same ordering and packing.
+library {{.Name}}CanonicalAbsorb {
+    uint256 internal constant LENGTH = {{len .Schema}};
+
+    function absorb({{range $i, $f := .Schema}}{{if $i}}, {{end}}uint256 {{$f}}{{end}}) internal pure returns (uint256[{{len .Schema | inc}}] memory out) {
+        out[0] = LENGTH;
+{{- range $i, $f := .Schema}}
+        out[{{inc $i}}] = {{$f}};
+{{- end}}
+    }
+
+    function pack({{range $i, $f := .Schema}}{{if $i}}, {{end}}uint256 {{$f}}{{end}}) internal pure returns (bytes memory) {
+        return abi.encodePacked(LENGTH{{range .Schema}}, {{.}}{{end}});
+    }
+}
+`))
+
+// ExportCanonicalSolidity writes a Solidity library reproducing the canonical
+// ordering and packing of schema, for contracts that feed inputs to a circuit.
+func ExportCanonicalSolidity(w io.Writer, name string, schema []string) error {
+	if len(schema) == 0 {
+		return fmt.Errorf("empty schema")
+	}
+	return canonicalSolidityTemplate.Execute(w, struct {
+		Name   string
+		Schema []string
+	}{name, schema})
+}
+
+var canonicalTypeScriptTemplate = template.Must(template.New("canonical").Parse(`// Code generated by hash_proof. DO NOT EDIT.
+
+// Canonical absorption order for {{.Name}}. MiMC itself is not computed
+// here; callers only need the same ordering and packing.
+export const LENGTH = {{len .Schema}}n;
+
+export function absorb({{range $i, $f := .Schema}}{{if $i}}, {{end}}{{$f}}: bigint{{end}}): bigint[] {
+  return [LENGTH{{range .Schema}}, {{.}}{{end}}];
+}
+
+// pack matches Solidity's abi.encodePacked over the absorbed uint256 words.
+export function pack({{range $i, $f := .Schema}}{{if $i}}, {{end}}{{$f}}: bigint{{end}}): Uint8Array {
+  const words = absorb({{range $i, $f := .Schema}}{{if $i}}, {{end}}{{$f}}{{end}});
+  const out = new Uint8Array(32 * words.length);
+  words.forEach((word, i) => {
+    if (word < 0n || word >= 1n << 256n) {
+      throw new RangeError("element " + i + " does not fit in a uint256");
+    }
+    for (let j = 31; j >= 0; j--, word >>= 8n) {
+      out[32 * i + j] = Number(word & 0xffn);
+    }
+  });
+  return out;
+}
+`))
+
+// ExportCanonicalTypeScript writes a TypeScript module reproducing the
+// canonical ordering and packing of schema, for clients that build the
+// inputs of a circuit or of a contract call.
+func ExportCanonicalTypeScript(w io.Writer, name string, schema []string) error {
+	if len(schema) == 0 {
+		return fmt.Errorf("empty schema")
+	}
+	return canonicalTypeScriptTemplate.Execute(w, struct {
+		Name   string
+		Schema []string
+	}{name, schema})
+}
@@ -0,0 +1,278 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type canonicalHashCircuit struct {
+	A    frontend.Variable `gnark:",secret"`
+	B    frontend.Variable `gnark:",secret"`
+	Hash frontend.Variable `gnark:",public"`
+}
+
+func (circuit *canonicalHashCircuit) Define(api frontend.API) error {
+	hash, err := canonicalHash(api, circuit.A, circuit.B)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.Hash, hash)
+	return nil
+}
+
+func TestCanonicalHashGolden(t *testing.T) {
+	testCases := []struct {
+		elems  map[string]*big.Int
+		schema []string
+		hash   string
+	}{
+		{
+			elems:  map[string]*big.Int{"secret": big.NewInt(35)},
+			schema: []string{"secret"},
+			hash:   "8353816632371637963699168551538612840774281601928138302989539381713599223441",
+		},
+		{
+			elems:  map[string]*big.Int{"secret": big.NewInt(35), "metadata": big.NewInt(42)},
+			schema: []string{"secret", "metadata"},
+			hash:   "8887478334534096781933084692704378995942781107919551849426129411294523354431",
+		},
+		{
+			elems:  map[string]*big.Int{"secret": big.NewInt(35), "metadata": big.NewInt(42)},
+			schema: []string{"metadata", "secret"},
+			hash:   "6846130560330083333017408249192263446275175315344776919115480244981036466278",
+		},
+	}
+
+	for _, tc := range testCases {
+		hash, err := CanonicalHash(ecc.BN254, tc.elems, tc.schema)
+		if err != nil {
+			t.Fatalf("Failed to compute canonical hash: %v", err)
+		}
+		if hash.String() != tc.hash {
+			t.Fatalf("Canonical hash for %v changed: got %s, want %s", tc.schema, hash, tc.hash)
+		}
+	}
+}
+
+func TestCanonicalAbsorbOrderIndependent(t *testing.T) {
+	schema := []string{"a", "b", "c"}
+
+	first := map[string]*big.Int{}
+	first["a"] = big.NewInt(1)
+	first["b"] = big.NewInt(2)
+	first["c"] = big.NewInt(3)
+
+	second := map[string]*big.Int{}
+	second["c"] = big.NewInt(3)
+	second["a"] = big.NewInt(1)
+	second["b"] = big.NewInt(2)
+
+	h1, err := CanonicalHash(ecc.BN254, first, schema)
+	if err != nil {
+		t.Fatalf("Failed to compute canonical hash: %v", err)
+	}
+	h2, err := CanonicalHash(ecc.BN254, second, schema)
+	if err != nil {
+		t.Fatalf("Failed to compute canonical hash: %v", err)
+	}
+	if h1.Cmp(h2) != 0 {
+		t.Fatal("Insertion order changed the canonical hash")
+	}
+}
+
+func TestCanonicalAbsorbSchemaMismatch(t *testing.T) {
+	elems := map[string]*big.Int{"a": big.NewInt(1), "b": big.NewInt(2)}
+
+	testCases := []struct {
+		name   string
+		schema []string
+		want   string
+	}{
+		{name: "too short", schema: []string{"a"}, want: "declares 1 elements"},
+		{name: "unknown name", schema: []string{"a", "c"}, want: `missing element "c"`},
+		{name: "duplicate name", schema: []string{"a", "a"}, want: `declares "a" twice`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CanonicalAbsorb(elems, tc.schema)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	tooBig := map[string]*big.Int{"a": ecc.BN254.ScalarField()}
+	if _, err := CanonicalHash(ecc.BN254, tooBig, []string{"a"}); err == nil {
+		t.Fatal("Expected an error for an element outside the field")
+	}
+}
+
+func TestCanonicalHashMatchesCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	schema := []string{"a", "b"}
+	elems := map[string]*big.Int{"a": big.NewInt(35), "b": big.NewInt(42)}
+	hash, err := CanonicalHash(ecc.BN254, elems, schema)
+	if err != nil {
+		t.Fatalf("Failed to compute canonical hash: %v", err)
+	}
+
+	var circuit canonicalHashCircuit
+	assert.ProverSucceeded(&circuit, &canonicalHashCircuit{
+		A:    35,
+		B:    42,
+		Hash: hash,
+	}, test.WithCurves(ecc.BN254))
+}
+
+func TestCanonicalPackingSolidity(t *testing.T) {
+	schema := []string{"secret", "metadata"}
+	absorbed, err := CanonicalAbsorb(map[string]*big.Int{
+		"secret":   big.NewInt(35),
+		"metadata": big.NewInt(42),
+	}, schema)
+	if err != nil {
+		t.Fatalf("Failed to absorb: %v", err)
+	}
+
+	packed := CanonicalPacking(absorbed)
+	if len(packed) != 96 || packed[31] != 2 || packed[63] != 35 || packed[95] != 42 {
+		t.Fatalf("Unexpected packing: %x", packed)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCanonicalSolidity(&buf, "Metadata", schema); err != nil {
+		t.Fatalf("Failed to export Solidity: %v", err)
+	}
+	code := buf.String()
+	for _, want := range []string{
+		"library MetadataCanonicalAbsorb",
+		"uint256 internal constant LENGTH = 2;",
+		"abi.encodePacked(LENGTH, secret, metadata)",
+		"out[1] = secret;",
+		"out[2] = metadata;",
+	} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("Exported Solidity is missing %q:\n%s", want, code)
+		}
+	}
+}
+
+// tsAnnotation matches the type annotations of the exported TypeScript, which
+// is all that separates it from JavaScript.
+var tsAnnotation = regexp.MustCompile(`: (bigint\[\]|bigint|Uint8Array)`)
+
+func TestCanonicalPackingTypeScript(t *testing.T) {
+	schema := []string{"secret", "metadata"}
+	absorbed, err := CanonicalAbsorb(map[string]*big.Int{
+		"secret":   big.NewInt(35),
+		"metadata": big.NewInt(42),
+	}, schema)
+	if err != nil {
+		t.Fatalf("Failed to absorb: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCanonicalTypeScript(&buf, "Metadata", schema); err != nil {
+		t.Fatalf("Failed to export TypeScript: %v", err)
+	}
+	code := buf.String()
+	for _, want := range []string{
+		"export const LENGTH = 2n;",
+		"export function absorb(secret: bigint, metadata: bigint): bigint[]",
+		"return [LENGTH, secret, metadata];",
+		"export function pack(secret: bigint, metadata: bigint): Uint8Array",
+	} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("Exported TypeScript is missing %q:\n%s", want, code)
+		}
+	}
+	if err := ExportCanonicalTypeScript(&buf, "Empty", nil); err == nil {
+		t.Fatal("Expected an error for an empty schema")
+	}
+
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node not installed")
+	}
+	dir := t.TempDir()
+	module := filepath.Join(dir, "metadata.mjs")
+	if err := os.WriteFile(module, []byte(tsAnnotation.ReplaceAllString(code, "")), 0644); err != nil {
+		t.Fatalf("Failed to write module: %v", err)
+	}
+	script := `import { pack } from "./metadata.mjs";
+process.stdout.write(Buffer.from(pack(35n, 42n)).toString("hex"));`
+	cmd := exec.Command("node", "--input-type=module", "-e", script)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("node failed: %v\n%s", err, out)
+	}
+	if want := hex.EncodeToString(CanonicalPacking(absorbed)); string(out) != want {
+		t.Fatalf("TypeScript packing differs from Go:\ngot  %s\nwant %s", out, want)
+	}
+}
+
+// TestMultiInputHashesAreCanonical checks that every native multi-input hash
+// absorbs its length before its elements.
+func TestMultiInputHashesAreCanonical(t *testing.T) {
+	a, b := big.NewInt(35), big.NewInt(42)
+	prefixed, err := MiMCHash(ecc.BN254, big.NewInt(2), a, b)
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+
+	node, err := merkleNode(ecc.BN254, a, b)
+	if err != nil {
+		t.Fatalf("Failed to hash Merkle node: %v", err)
+	}
+	vote, err := MajorityVoteHash(ecc.BN254, a, b)
+	if err != nil {
+		t.Fatalf("Failed to hash vote: %v", err)
+	}
+	mimcCommitment, poseidonCommitment, err := BridgeCommitments(ecc.BN254, a, b, b)
+	if err != nil {
+		t.Fatalf("Failed to compute bridge commitments: %v", err)
+	}
+	poseidonPrefixed, err := GadgetHash(PoseidonGadget{}, ecc.BN254, big.NewInt(2), a, b)
+	if err != nil {
+		t.Fatalf("Failed to compute Poseidon2 hash: %v", err)
+	}
+	tree, err := NewIntervalTree(ecc.BN254, [][2]*big.Int{{a, b}})
+	if err != nil {
+		t.Fatalf("Failed to build interval tree: %v", err)
+	}
+	positions, err := BloomPositions(ecc.BN254, b, [BloomHashes]*big.Int{a, a, a})
+	if err != nil {
+		t.Fatalf("Failed to compute Bloom positions: %v", err)
+	}
+
+	for _, c := range []struct {
+		name      string
+		got, want *big.Int
+	}{
+		{"Merkle node", node, prefixed},
+		{"majority vote", vote, prefixed},
+		{"interval leaf", tree.levels[0][0], prefixed},
+		{"bridge MiMC", mimcCommitment, prefixed},
+		{"bridge Poseidon2", poseidonCommitment, poseidonPrefixed},
+	} {
+		if c.got.Cmp(c.want) != 0 {
+			t.Fatalf("%s is not the canonical hash: got %s, want %s", c.name, c.got, c.want)
+		}
+	}
+	if want := int(new(big.Int).Mod(prefixed, big.NewInt(BloomBits)).Int64()); positions[0] != want {
+		t.Fatalf("Bloom position is not derived from the canonical hash: got %d, want %d", positions[0], want)
+	}
+}
@@ -0,0 +1,118 @@
+package hash_proof
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Descriptor lists what this package supports, for tools that build their
+// options at runtime instead of hard-coding them.
+type Descriptor struct {
+	Curves        []string            `json:"curves"`
+	Circuits      []string            `json:"circuits"`
+	Hashes        []string            `json:"hashes"`
+	Backends      []string            `json:"backends"`
+	ExportFormats []string            `json:"exportFormats"`
+	Matrix        []CurveCapabilities `json:"matrix"`
+}
+
+// CurveCapabilities is the row of the capability matrix for one curve.
+type CurveCapabilities struct {
+	Curve string `json:"curve"`
+	// Hashes are the gadgets with a native helper on this curve.
+	Hashes []string `json:"hashes"`
+	// SolidityExport is whether an EVM verifier can be exported.
+	SolidityExport bool `json:"solidityExport"`
+	// Circuits are the registered circuits that build on this curve.
+	Circuits []string `json:"circuits"`
+}
+
+// CapabilitySolidityExport is the feature CapabilityError names when no EVM
+// verifier can be exported; missing native hashes are named "native MiMC"
+// and so on.
+const CapabilitySolidityExport = "solidity export"
+
+// CapabilityError reports a feature this build does not offer on a curve.
+type CapabilityError struct {
+	Feature string
+	Curve   ecc.ID
+}
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("this build does not include %s for %s", e.Feature, e.Curve)
+}
+
+// Capabilities describes the supported curves (those with a native MiMC),
+// the circuits NewAssignment can build, the hash gadgets, the proving
+// backends and the export formats, with a per-curve matrix of which of them
+// combine. Only Groth16 proofs and the Solidity verifier and calldata
+// encodings are produced; there is no PLONK backend and no snarkjs or
+// TypeScript export.
+func Capabilities() Descriptor {
+	d := Descriptor{
+		Backends:      []string{"groth16"},
+		ExportFormats: []string{"solidity", "calldata"},
+		Matrix:        capabilityMatrix(),
+	}
+	for curveID := range mimcByCurve {
+		d.Curves = append(d.Curves, curveID.String())
+	}
+	for name := range assignableCircuits {
+		d.Circuits = append(d.Circuits, name)
+	}
+	for _, gadget := range hashGadgets {
+		d.Hashes = append(d.Hashes, strings.ToLower(gadget.Name()))
+	}
+	slices.Sort(d.Curves)
+	slices.Sort(d.Circuits)
+	return d
+}
+
+var hashGadgets = []HashGadget{MiMCGadget{}, PoseidonGadget{}}
+
+// groth16Curves are the curves gnark's Groth16 backend proves on.
+var groth16Curves = []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_761, ecc.BW6_633}
+
+// capabilityMatrix builds the per-curve rows from the static declarations:
+// native hashes, Solidity export, and circuitCurves. Nothing is compiled.
+func capabilityMatrix() []CurveCapabilities {
+	names := make([]string, 0, len(assignableCircuits))
+	for name := range assignableCircuits {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var matrix []CurveCapabilities
+	for _, curveID := range groth16Curves {
+		row := CurveCapabilities{
+			Curve:          curveID.String(),
+			Hashes:         []string{},
+			SolidityExport: curveID == ecc.BN254,
+			Circuits:       []string{},
+		}
+		for _, gadget := range hashGadgets {
+			if _, ok := gadget.Native(curveID); ok {
+				row.Hashes = append(row.Hashes, strings.ToLower(gadget.Name()))
+			}
+		}
+		for _, name := range names {
+			if circuitSupports(name, curveID) {
+				row.Circuits = append(row.Circuits, name)
+			}
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix
+}
+
+// requireSolidityExport fails fast with a CapabilityError for curves gnark
+// cannot export an EVM verifier for.
+func requireSolidityExport(curveID ecc.ID) error {
+	if curveID != ecc.BN254 {
+		return &CapabilityError{Feature: CapabilitySolidityExport, Curve: curveID}
+	}
+	return nil
+}
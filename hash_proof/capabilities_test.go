@@ -0,0 +1,198 @@
+package hash_proof
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestCapabilities(t *testing.T) {
+	d := Capabilities()
+
+	if !slices.Contains(d.Curves, ecc.BN254.String()) {
+		t.Fatalf("Curves do not include BN254: %v", d.Curves)
+	}
+	if !slices.Contains(d.Hashes, "mimc") {
+		t.Fatalf("Hashes do not include mimc: %v", d.Hashes)
+	}
+	if !slices.Contains(d.Backends, "groth16") {
+		t.Fatalf("Backends do not include groth16: %v", d.Backends)
+	}
+
+	if len(d.Curves) != len(mimcByCurve) {
+		t.Fatalf("Expected %d curves, got %v", len(mimcByCurve), d.Curves)
+	}
+	if len(d.Circuits) != len(assignableCircuits) {
+		t.Fatalf("Expected %d circuits, got %v", len(assignableCircuits), d.Circuits)
+	}
+	for _, name := range d.Circuits {
+		if _, ok := assignableCircuits[name]; !ok {
+			t.Fatalf("Circuit %s is not in the registry", name)
+		}
+	}
+}
+
+func TestCapabilityMatrix(t *testing.T) {
+	d := Capabilities()
+
+	if len(d.Matrix) != len(groth16Curves) {
+		t.Fatalf("Expected a row per Groth16 curve, got %d", len(d.Matrix))
+	}
+	for _, row := range d.Matrix {
+		var curveID ecc.ID
+		for _, id := range groth16Curves {
+			if id.String() == row.Curve {
+				curveID = id
+			}
+		}
+		if curveID == ecc.UNKNOWN {
+			t.Fatalf("Unknown curve %s in matrix", row.Curve)
+		}
+
+		_, native := mimcByCurve[curveID]
+		if slices.Contains(row.Hashes, "mimc") != native {
+			t.Fatalf("%s: matrix says native MiMC is %v", row.Curve, !native)
+		}
+		_, err := MiMCHash(curveID, big.NewInt(35))
+		var capErr *CapabilityError
+		if native != (err == nil) || (!native && !errors.As(err, &capErr)) {
+			t.Fatalf("%s: MiMCHash does not agree with the matrix: %v", row.Curve, err)
+		}
+
+		if row.SolidityExport != (requireSolidityExport(curveID) == nil) {
+			t.Fatalf("%s: Solidity export does not agree with the matrix", row.Curve)
+		}
+		if !slices.Contains(row.Circuits, "HashCircuit[MiMC]") {
+			t.Fatalf("%s: HashCircuit does not compile: %v", row.Curve, row.Circuits)
+		}
+	}
+
+	bn254 := d.Matrix[0]
+	if bn254.Curve != "bn254" || !bn254.SolidityExport || len(bn254.Circuits) != len(assignableCircuits) {
+		t.Fatalf("Unexpected BN254 row: %+v", bn254)
+	}
+	// Pedersen runs on Baby Jubjub, whose base field is BN254's scalar field.
+	for _, row := range d.Matrix {
+		if slices.Contains(row.Circuits, "PedersenMiMCCircuit") != (row.Curve == "bn254") {
+			t.Fatalf("%s: PedersenMiMCCircuit should compile on BN254 only", row.Curve)
+		}
+	}
+	// Poseidon2 has no BLS24 parameters, so its circuits do not compile there.
+	for _, row := range d.Matrix {
+		if strings.HasPrefix(row.Curve, "bls24") && slices.Contains(row.Circuits, "HashCircuit[Poseidon2]") {
+			t.Fatalf("%s: unexpected Poseidon2 circuit", row.Curve)
+		}
+	}
+
+	_, err := MiMCHash(ecc.BLS24_315, big.NewInt(35))
+	if err == nil || err.Error() != "this build does not include native MiMC for bls24_315" {
+		t.Fatalf("Unexpected error for a missing native hash: %v", err)
+	}
+	if err := requireSolidityExport(ecc.BLS12_381); err == nil || !strings.Contains(err.Error(), "does not include solidity export") {
+		t.Fatalf("Unexpected error for a curve without Solidity export: %v", err)
+	}
+}
+
+// TestCircuitCurves compiles every registered circuit on every Groth16 curve
+// and checks the result against circuitCurves, which Capabilities reports
+// without compiling anything.
+func TestCircuitCurves(t *testing.T) {
+	for name, newCircuit := range assignableCircuits {
+		for _, curveID := range groth16Curves {
+			_, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, newCircuit())
+			if supported := circuitSupports(name, curveID); supported != (err == nil) {
+				t.Errorf("%s on %s: circuitCurves says %v, compiling gives %v", name, curveID, supported, err)
+			}
+		}
+	}
+	for name := range circuitCurves {
+		if _, ok := assignableCircuits[name]; !ok {
+			t.Errorf("circuitCurves lists unregistered circuit %s", name)
+		}
+	}
+}
+
+// TestAssignableCircuitsComplete parses the package source so that a new
+// circuit with only scalar inputs cannot be left out of assignableCircuits,
+// and with it Capabilities and the capability matrix.
+func TestAssignableCircuitsComplete(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Failed to list sources: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	structs := map[string]*ast.TypeSpec{}
+	circuits := map[string]bool{}
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						structs[ts.Name.Name] = ts
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Name.Name != "Define" || decl.Recv == nil {
+					continue
+				}
+				recv := decl.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					circuits[ident.Name] = true
+				}
+			}
+		}
+	}
+
+	for name := range circuits {
+		ts := structs[name]
+		if ts == nil || ts.TypeParams != nil || !ast.IsExported(name) {
+			continue
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok || !scalarFields(st) {
+			continue
+		}
+		if _, ok := assignableCircuits[name]; !ok {
+			t.Errorf("%s has only scalar inputs but is not in assignableCircuits", name)
+		}
+	}
+}
+
+// scalarFields reports whether every field of st is a frontend.Variable.
+func scalarFields(st *ast.StructType) bool {
+	if len(st.Fields.List) == 0 {
+		return false
+	}
+	for _, field := range st.Fields.List {
+		sel, ok := field.Type.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Variable" {
+			return false
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "frontend" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// ChecksummedListSize is the number of elements ChecksummedListCircuit
+// commits to.
+const ChecksummedListSize = 4
+
+// ChecksummedListCircuit proves knowledge of secret elements, each committed
+// as Hashes[i] = MiMC(Elements[i]), whose field sum hashes to Checksum. A
+// list that matches its per-element commitments but not the checksum, or the
+// other way around, does not prove.
+type ChecksummedListCircuit struct {
+	Elements [ChecksummedListSize]frontend.Variable `gnark:",secret"`
+	Hashes   [ChecksummedListSize]frontend.Variable `gnark:",public"`
+	Checksum frontend.Variable                      `gnark:",public"`
+}
+
+func (circuit *ChecksummedListCircuit) Define(api frontend.API) error {
+	var sum frontend.Variable = 0
+	for i := range circuit.Elements {
+		hFunc, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		hFunc.Write(circuit.Elements[i])
+		api.AssertIsEqual(circuit.Hashes[i], hFunc.Sum())
+
+		sum = api.Add(sum, circuit.Elements[i])
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hFunc.Write(sum)
+	api.AssertIsEqual(circuit.Checksum, hFunc.Sum())
+
+	return nil
+}
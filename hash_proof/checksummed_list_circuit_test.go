@@ -0,0 +1,47 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestChecksummedListCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit ChecksummedListCircuit
+
+	elements := [ChecksummedListSize]int64{3, 5, 8, 13}
+	assignment := &ChecksummedListCircuit{}
+	var sum int64
+	for i, e := range elements {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(e))
+		if err != nil {
+			t.Fatalf("Failed to hash element: %v", err)
+		}
+		assignment.Elements[i], assignment.Hashes[i] = e, hash
+		sum += e
+	}
+	checksum, err := MiMCHash(ecc.BN254, big.NewInt(sum))
+	if err != nil {
+		t.Fatalf("Failed to hash checksum: %v", err)
+	}
+	assignment.Checksum = checksum
+
+	assert.ProverSucceeded(&circuit, assignment, test.WithCurves(ecc.BN254))
+
+	// One element altered and recommitted, with the checksum left as is.
+	altered := *assignment
+	altered.Elements[2] = 9
+	if altered.Hashes[2], err = MiMCHash(ecc.BN254, big.NewInt(9)); err != nil {
+		t.Fatalf("Failed to hash element: %v", err)
+	}
+	assert.ProverFailed(&circuit, &altered, test.WithCurves(ecc.BN254))
+
+	// One element altered without updating its commitment either.
+	altered = *assignment
+	altered.Elements[2] = 9
+	assert.ProverFailed(&circuit, &altered, test.WithCurves(ecc.BN254))
+}
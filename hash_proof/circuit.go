@@ -2,16 +2,27 @@ package hash_proof
 
 import (
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/std/hash/mimc"
 )
 
-type HashCircuit struct {
+// HashCircuit proves knowledge of the MiMC preimage of Hash.
+type HashCircuit = HashCircuitOf[MiMCGadget]
+
+// Poseidon2HashCircuit proves knowledge of the Poseidon2 preimage of Hash.
+// The permutation has width 2 with gnark-crypto's default rounds for the
+// curve (6 full rounds; 50 partial rounds on BN254, BLS12-381 and BW6-761,
+// 26 on BLS12-377) and is used in Merkle-Damgård mode with a zero IV, as
+// Poseidon2Hash computes natively.
+type Poseidon2HashCircuit = HashCircuitOf[PoseidonGadget]
+
+// HashCircuitOf is HashCircuit with the hash gadget chosen by G.
+type HashCircuitOf[G HashGadget] struct {
 	PreImage frontend.Variable `gnark:",secret"`
 	Hash     frontend.Variable `gnark:",public"`
 }
 
-func (circuit *HashCircuit) Define(api frontend.API) error {
-	hFunc, err := mimc.NewMiMC(api)
+func (circuit *HashCircuitOf[G]) Define(api frontend.API) error {
+	var gadget G
+	hFunc, err := gadget.New(api)
 	if err != nil {
 		return err
 	}
@@ -23,3 +34,14 @@ func (circuit *HashCircuit) Define(api frontend.API) error {
 
 	return nil
 }
+
+func (circuit *HashCircuitOf[G]) CircuitName() string {
+	var gadget G
+	return "HashCircuit[" + gadget.Name() + "]"
+}
+
+// Gadget returns the hash gadget the circuit is built with.
+func (circuit *HashCircuitOf[G]) Gadget() HashGadget {
+	var gadget G
+	return gadget
+}
@@ -287,6 +287,12 @@ func TestHashCircuitMultipleCurves(t *testing.T) {
 			hash:     "2474112249751028531650252582366798049474486386634137916759752348728204118534",
 			curve:    ecc.BN254,
 		},
+		{
+			name:     "BW6_633",
+			preImage: 35,
+			hash:     "9968387491322785805085586073883329841826030190403950669068884412494573739951963330296838078171",
+			curve:    ecc.BW6_633,
+		},
 	}
 
 	for _, tc := range testCases {
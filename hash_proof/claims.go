@@ -0,0 +1,75 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"golang.org/x/crypto/sha3"
+)
+
+// claims is the canonical JSON form of a public witness. encoding/json
+// writes struct fields in declaration order and map keys sorted, without
+// whitespace; values are decimal strings so no JSON number precision is
+// involved.
+type claims struct {
+	Circuit string            `json:"circuit"`
+	Curve   string            `json:"curve"`
+	Inputs  map[string]string `json:"inputs"`
+}
+
+// CanonicalClaims returns the canonical JSON of the named public inputs of
+// pub, with the circuit name and curve, and its keccak256 digest, for tools
+// that treat a proof's public inputs as signed claims. The encoding is
+// stable across releases:
+//
+//	{"circuit":"HashCircuit[MiMC]","curve":"bn254","inputs":{"Hash":"247..."}}
+func CanonicalClaims(circuit frontend.Circuit, pub witness.Witness, curveID ecc.ID) ([]byte, [32]byte, error) {
+	var digest [32]byte
+	inputs, err := DescribePublicWitness(circuit, pub, curveID)
+	if err != nil {
+		return nil, digest, err
+	}
+
+	data, err := json.Marshal(claims{
+		Circuit: CircuitName(circuit),
+		Curve:   curveID.String(),
+		Inputs:  inputs,
+	})
+	if err != nil {
+		return nil, digest, err
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(digest[:0])
+	return data, digest, nil
+}
+
+// ClaimsMatch checks that canonicalJSON is exactly the canonical claims of
+// pub. Semantically equal JSON with other key order, whitespace or number
+// encoding is rejected, since its digest would differ.
+func ClaimsMatch(circuit frontend.Circuit, pub witness.Witness, curveID ecc.ID, canonicalJSON []byte) error {
+	want, _, err := CanonicalClaims(circuit, pub, curveID)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(canonicalJSON, want) {
+		return nil
+	}
+
+	var got claims
+	if err := json.Unmarshal(canonicalJSON, &got); err != nil {
+		return fmt.Errorf("claims cannot be decoded: %w", err)
+	}
+	if got.Circuit != CircuitName(circuit) || got.Curve != curveID.String() {
+		return fmt.Errorf("claims are for %s over %s, not %s over %s", got.Circuit, got.Curve, CircuitName(circuit), curveID)
+	}
+	if reencoded, err := json.Marshal(got); err == nil && bytes.Equal(reencoded, want) {
+		return fmt.Errorf("claims match but are not canonically encoded")
+	}
+	return fmt.Errorf("claims do not match the public inputs")
+}
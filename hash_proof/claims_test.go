@@ -0,0 +1,71 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestCanonicalClaims(t *testing.T) {
+	hash, err := MiMCHash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	pub, err := frontend.NewWitness(&HashCircuit{Hash: hash}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	data, digest, err := CanonicalClaims(&HashCircuit{}, pub, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to build claims: %v", err)
+	}
+
+	path := filepath.Join("testdata", "claims_hash_circuit.json")
+	if *updateGolden {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(data, golden) {
+		t.Fatalf("Canonical claims changed:\ngot  %s\nwant %s", data, golden)
+	}
+	const expectedDigest = "d73f950df73e9fd2ad475cbe9e3bcdf6d14ce6dfa9ff78adf59c237198ccab8b"
+	if got := hex.EncodeToString(digest[:]); got != expectedDigest {
+		t.Fatalf("Unexpected claims digest: got %s, want %s", got, expectedDigest)
+	}
+
+	if err := ClaimsMatch(&HashCircuit{}, pub, ecc.BN254, golden); err != nil {
+		t.Fatalf("Canonical claims rejected: %v", err)
+	}
+
+	hashValue := hash.String()
+	for name, tc := range map[string]struct {
+		claims string
+		reason string
+	}{
+		"whitespace":    {`{"circuit": "HashCircuit[MiMC]", "curve": "bn254", "inputs": {"Hash": "` + hashValue + `"}}`, "not canonically encoded"},
+		"key order":     {`{"curve":"bn254","circuit":"HashCircuit[MiMC]","inputs":{"Hash":"` + hashValue + `"}}`, "not canonically encoded"},
+		"number":        {`{"circuit":"HashCircuit[MiMC]","curve":"bn254","inputs":{"Hash":` + hashValue + `}}`, "cannot be decoded"},
+		"hex value":     {`{"circuit":"HashCircuit[MiMC]","curve":"bn254","inputs":{"Hash":"0x` + hash.Text(16) + `"}}`, "do not match"},
+		"other circuit": {`{"circuit":"RotationCircuit","curve":"bn254","inputs":{"Hash":"` + hashValue + `"}}`, "RotationCircuit"},
+		"other curve":   {`{"circuit":"HashCircuit[MiMC]","curve":"bls12_381","inputs":{"Hash":"` + hashValue + `"}}`, "bls12_381"},
+		"other input":   {`{"circuit":"HashCircuit[MiMC]","curve":"bn254","inputs":{"Hash":"42"}}`, "do not match"},
+	} {
+		err := ClaimsMatch(&HashCircuit{}, pub, ecc.BN254, []byte(tc.claims))
+		if err == nil || !strings.Contains(err.Error(), tc.reason) {
+			t.Fatalf("Unexpected result for %s claims: %v", name, err)
+		}
+	}
+}
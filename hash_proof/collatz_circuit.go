@@ -0,0 +1,52 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+const (
+	// CollatzMaxSteps is the longest stopping time CollatzHashCircuit can
+	// prove; every step is unrolled up to it.
+	CollatzMaxSteps = 128
+	// CollatzValueBits bounds every value of the sequence, so 3x+1 cannot
+	// wrap around the field.
+	CollatzValueBits = 64
+)
+
+// CollatzHashCircuit proves that the Collatz sequence starting at the
+// preimage of Hash reaches 1 in exactly Steps steps, halving even values and
+// mapping odd ones to 3x+1.
+type CollatzHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Steps    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *CollatzHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	// Once x reaches 1 it stays there and steps stop being counted.
+	x := circuit.PreImage
+	var stopped, steps frontend.Variable = 0, 0
+	for i := 0; i < CollatzMaxSteps; i++ {
+		stopped = api.Or(stopped, api.IsZero(api.Sub(x, 1)))
+
+		bits := api.ToBinary(x, CollatzValueBits)
+		half := api.FromBinary(bits[1:]...)
+		next := api.Select(bits[0], api.Add(api.Mul(x, 3), 1), half)
+
+		x = api.Select(stopped, x, next)
+		steps = api.Add(steps, api.Sub(1, stopped))
+	}
+	api.AssertIsEqual(x, 1)
+	api.AssertIsEqual(circuit.Steps, steps)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestCollatzHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit CollatzHashCircuit
+
+	assignment := func(seed, steps int64) *CollatzHashCircuit {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(seed))
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		return &CollatzHashCircuit{PreImage: seed, Hash: hash, Steps: steps}
+	}
+
+	// 27 climbs to 9232 before reaching 1 after 111 steps.
+	for _, tc := range []struct{ seed, steps int64 }{{1, 0}, {6, 8}, {27, 111}} {
+		assert.ProverSucceeded(&circuit, assignment(tc.seed, tc.steps), test.WithCurves(ecc.BN254))
+		assert.ProverFailed(&circuit, assignment(tc.seed, tc.steps+1), test.WithCurves(ecc.BN254))
+	}
+
+	// 871 needs 178 steps, more than CollatzMaxSteps; 0 never reaches 1.
+	assert.ProverFailed(&circuit, assignment(871, 178), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(0, 0), test.WithCurves(ecc.BN254))
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	t.Logf("CollatzHashCircuit (%d steps) constraints: %d", CollatzMaxSteps, ccs.GetNbConstraints())
+}
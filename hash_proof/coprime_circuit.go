@@ -0,0 +1,47 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// CoprimeModulusBits bounds the secret and the public modulus of
+// CoprimeHashCircuit so that secret*inverse stays below the field modulus.
+const CoprimeModulusBits = 120
+
+// CoprimeHashCircuit proves knowledge of the preimage of Hash and that it is
+// co-prime to the public Modulus, by exhibiting its inverse modulo Modulus.
+type CoprimeHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Modulus  frontend.Variable `gnark:",public"`
+}
+
+func (circuit *CoprimeHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	out, err := api.Compiler().NewHint(modInverseHint, 2, circuit.PreImage, circuit.Modulus)
+	if err != nil {
+		return err
+	}
+	inv, q := out[0], out[1]
+
+	rc := rangecheck.New(api)
+	rc.Check(circuit.PreImage, CoprimeModulusBits)
+	rc.Check(circuit.Modulus, CoprimeModulusBits)
+	rc.Check(inv, CoprimeModulusBits)
+	rc.Check(q, CoprimeModulusBits)
+	api.AssertIsLessOrEqual(api.Add(inv, 1), circuit.Modulus)
+
+	// secret*inv = q*N + 1 over the integers, i.e. secret*inv ≡ 1 (mod N).
+	api.AssertIsEqual(api.Mul(circuit.PreImage, inv), api.Add(api.Mul(q, circuit.Modulus), 1))
+
+	return nil
+}
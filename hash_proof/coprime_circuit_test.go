@@ -0,0 +1,30 @@
+package hash_proof
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestCoprimeHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit CoprimeHashCircuit
+
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+
+	// 35 = 5 * 7 is co-prime to 3 * 11 * 13.
+	assert.ProverSucceeded(&circuit, &CoprimeHashCircuit{
+		PreImage: 35,
+		Hash:     hash,
+		Modulus:  3 * 11 * 13,
+	}, test.WithCurves(ecc.BN254))
+
+	// 35 shares the factor 7 with 3 * 7 * 13.
+	assert.ProverFailed(&circuit, &CoprimeHashCircuit{
+		PreImage: 35,
+		Hash:     hash,
+		Modulus:  3 * 7 * 13,
+	}, test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,76 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+var daysInMonth = [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// ValidDateHashCircuit proves knowledge of the preimage of Hash and that it
+// is a calendar date packed as the decimal integer YYYYMMDD, with leap years
+// following the Gregorian rules.
+type ValidDateHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+}
+
+func (circuit *ValidDateHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	// YYYYMMDD = (YYYY*100 + MM)*100 + DD
+	yearMonth, day, err := divMod(api, circuit.PreImage, 100, 20, 7)
+	if err != nil {
+		return err
+	}
+	year, month, err := divMod(api, yearMonth, 100, 14, 7)
+	if err != nil {
+		return err
+	}
+	api.AssertIsLessOrEqual(year, 9999)
+
+	api.AssertIsDifferent(month, 0)
+	api.AssertIsLessOrEqual(month, 12)
+
+	leap, err := isLeapYear(api, year)
+	if err != nil {
+		return err
+	}
+
+	var maxDay frontend.Variable = 0
+	for i, days := range daysInMonth {
+		maxDay = api.Add(maxDay, api.Mul(api.IsZero(api.Sub(month, i+1)), days))
+	}
+	maxDay = api.Add(maxDay, api.Mul(api.IsZero(api.Sub(month, 2)), leap))
+
+	api.AssertIsDifferent(day, 0)
+	api.AssertIsLessOrEqual(day, maxDay)
+
+	return nil
+}
+
+// isLeapYear returns 1 if year is divisible by 4 and either not by 100 or by
+// 400, and 0 otherwise.
+func isLeapYear(api frontend.API, year frontend.Variable) (frontend.Variable, error) {
+	_, mod4, err := divMod(api, year, 4, 12, 2)
+	if err != nil {
+		return nil, err
+	}
+	_, mod100, err := divMod(api, year, 100, 7, 7)
+	if err != nil {
+		return nil, err
+	}
+	_, mod400, err := divMod(api, year, 400, 5, 9)
+	if err != nil {
+		return nil, err
+	}
+
+	notCentury := api.Sub(1, api.IsZero(mod100))
+	return api.And(api.IsZero(mod4), api.Or(notCentury, api.IsZero(mod400))), nil
+}
@@ -0,0 +1,45 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestValidDateHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit ValidDateHashCircuit
+
+	assignment := func(date int64) *ValidDateHashCircuit {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(date))
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		return &ValidDateHashCircuit{PreImage: date, Hash: hash}
+	}
+
+	for _, date := range []int64{
+		19700101,
+		20241231,
+		20240229, // divisible by 4
+		20000229, // divisible by 400
+		20230430,
+	} {
+		assert.ProverSucceeded(&circuit, assignment(date), test.WithCurves(ecc.BN254))
+	}
+
+	for _, date := range []int64{
+		20241301, // month 13
+		20240001, // month 0
+		20240100, // day 0
+		20230229, // not a leap year
+		19000229, // divisible by 100 but not 400
+		20230431, // April has 30 days
+		120240101,
+	} {
+		assert.ProverFailed(&circuit, assignment(date), test.WithCurves(ecc.BN254))
+	}
+}
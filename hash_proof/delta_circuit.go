@@ -0,0 +1,51 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// HashDeltaCircuit proves knowledge of two secrets whose MiMC hashes differ by
+// the public Delta. The difference is taken in the field, so Delta is
+// MiMC(B) - MiMC(A) modulo the scalar field and wraps around when MiMC(B) is
+// the smaller integer.
+type HashDeltaCircuit struct {
+	A     frontend.Variable `gnark:",secret"`
+	B     frontend.Variable `gnark:",secret"`
+	Delta frontend.Variable `gnark:",public"`
+}
+
+func (circuit *HashDeltaCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.A)
+	hashA := hFunc.Sum()
+
+	hFunc.Reset()
+	hFunc.Write(circuit.B)
+	hashB := hFunc.Sum()
+
+	api.AssertIsEqual(circuit.Delta, api.Sub(hashB, hashA))
+
+	return nil
+}
+
+// HashDelta computes the public Delta of HashDeltaCircuit for secrets a and b.
+func HashDelta(curveID ecc.ID, a, b *big.Int) (*big.Int, error) {
+	hashA, err := MiMCHash(curveID, a)
+	if err != nil {
+		return nil, err
+	}
+	hashB, err := MiMCHash(curveID, b)
+	if err != nil {
+		return nil, err
+	}
+	delta := new(big.Int).Sub(hashB, hashA)
+	return delta.Mod(delta, curveID.ScalarField()), nil
+}
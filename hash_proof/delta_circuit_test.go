@@ -0,0 +1,63 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestHashDeltaCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit HashDeltaCircuit
+
+	a, b := big.NewInt(35), big.NewInt(36)
+	hashA, err := MiMCHash(ecc.BN254, a)
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	hashB, err := MiMCHash(ecc.BN254, b)
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+
+	delta, err := HashDelta(ecc.BN254, a, b)
+	if err != nil {
+		t.Fatalf("Failed to compute hash delta: %v", err)
+	}
+	reversed, err := HashDelta(ecc.BN254, b, a)
+	if err != nil {
+		t.Fatalf("Failed to compute hash delta: %v", err)
+	}
+
+	// One of the two orders has a negative integer difference and must wrap.
+	if hashA.Cmp(hashB) < 0 {
+		t.Log("MiMC(b) > MiMC(a): the reversed delta wraps around the field")
+	} else {
+		t.Log("MiMC(a) > MiMC(b): the delta wraps around the field")
+	}
+	sum := new(big.Int).Add(delta, reversed)
+	if sum.Mod(sum, ecc.BN254.ScalarField()).Sign() != 0 {
+		t.Fatal("Opposite deltas must sum to zero in the field")
+	}
+
+	assert.ProverSucceeded(&circuit, &HashDeltaCircuit{
+		A:     a,
+		B:     b,
+		Delta: delta,
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverSucceeded(&circuit, &HashDeltaCircuit{
+		A:     b,
+		B:     a,
+		Delta: reversed,
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverFailed(&circuit, &HashDeltaCircuit{
+		A:     a,
+		B:     b,
+		Delta: reversed,
+	}, test.WithCurves(ecc.BN254))
+}
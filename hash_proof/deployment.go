@@ -0,0 +1,85 @@
+package hash_proof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+)
+
+// DeploymentManifest is everything an operator needs to deploy the verifier
+// for a circuit and to check afterwards that the right one was deployed.
+// Fingerprints are those of Fingerprint.
+type DeploymentManifest struct {
+	Curve               string      `json:"curve"`
+	CircuitHash         string      `json:"circuitHash"`
+	VKFingerprint       string      `json:"vkFingerprint"`
+	VerifierFingerprint string      `json:"verifierFingerprint"`
+	Constraints         int         `json:"constraints"`
+	PublicInputs        int         `json:"publicInputs"`
+	Commitments         int         `json:"commitments,omitempty"`
+	EstimatedGas        GasEstimate `json:"estimatedGas"`
+	SolidityPragma      string      `json:"solidityPragma"`
+}
+
+var solidityPragma = regexp.MustCompile(`pragma solidity ([^;]+);`)
+
+// GenerateDeploymentManifest describes the Solidity verifier of vk, which
+// must come from the setup of ccs over curveID. The verifier fingerprint is
+// the SHA-256 of the exported contract source and the pragma is read from
+// it, so both always match what ExportSolidity writes. Commitments counts
+// the BSB22 commitments of circuits using rangecheck, whose verifyProof
+// takes the commitments and their proof of knowledge before the inputs.
+func GenerateDeploymentManifest(vk groth16.VerifyingKey, ccs constraint.ConstraintSystem, curveID ecc.ID) (DeploymentManifest, error) {
+	ccsCurve, err := curveOf(ccs)
+	if err != nil {
+		return DeploymentManifest{}, err
+	}
+	if vk.CurveID() != curveID || ccsCurve != curveID {
+		return DeploymentManifest{}, fmt.Errorf("verifying key is over %s and constraint system over %s, expected %s", vk.CurveID(), ccsCurve, curveID)
+	}
+	// The constant one wire is public but not an input. The verifying key
+	// also has one public wire per commitment, its hash.
+	nbPublic := ccs.GetNbPublicVariables() - 1
+	nbCommitments := len(ccs.GetCommitments().CommitmentIndexes())
+	if nbPublic+nbCommitments != vk.NbPublicWitness() {
+		return DeploymentManifest{}, fmt.Errorf("verifying key expects %d public inputs, constraint system declares %d and %d commitments", vk.NbPublicWitness(), nbPublic, nbCommitments)
+	}
+
+	if err := requireSolidityExport(curveID); err != nil {
+		return DeploymentManifest{}, err
+	}
+	var verifier bytes.Buffer
+	if err := vk.ExportSolidity(&verifier); err != nil {
+		return DeploymentManifest{}, fmt.Errorf("cannot export Solidity verifier: %w", err)
+	}
+	pragma := solidityPragma.FindSubmatch(verifier.Bytes())
+	if pragma == nil {
+		return DeploymentManifest{}, fmt.Errorf("exported verifier has no pragma")
+	}
+	verifierHash := sha256.Sum256(verifier.Bytes())
+
+	m := DeploymentManifest{
+		Curve:               curveID.String(),
+		VerifierFingerprint: hex.EncodeToString(verifierHash[:]),
+		Constraints:         ccs.GetNbConstraints(),
+		PublicInputs:        nbPublic,
+		Commitments:         nbCommitments,
+		SolidityPragma:      string(pragma[1]),
+	}
+	if m.CircuitHash, err = Fingerprint(ccs); err != nil {
+		return DeploymentManifest{}, err
+	}
+	if m.VKFingerprint, err = Fingerprint(vk); err != nil {
+		return DeploymentManifest{}, err
+	}
+	if m.EstimatedGas, err = EstimateVerifyGas(m.PublicInputs, m.Commitments); err != nil {
+		return DeploymentManifest{}, err
+	}
+	return m, nil
+}
@@ -0,0 +1,100 @@
+package hash_proof_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestGenerateDeploymentManifest(t *testing.T) {
+	ccs, _, vk, _, _ := testutil.GenerateFixture(t, ecc.BN254)
+
+	m, err := hash_proof.GenerateDeploymentManifest(vk, ccs, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to generate deployment manifest: %v", err)
+	}
+
+	circuitHash, err := hash_proof.Fingerprint(ccs)
+	if err != nil {
+		t.Fatalf("Failed to fingerprint circuit: %v", err)
+	}
+	vkFingerprint, err := hash_proof.Fingerprint(vk)
+	if err != nil {
+		t.Fatalf("Failed to fingerprint verifying key: %v", err)
+	}
+	var verifier bytes.Buffer
+	if err := vk.ExportSolidity(&verifier); err != nil {
+		t.Fatalf("Failed to export Solidity verifier: %v", err)
+	}
+	verifierHash := sha256.Sum256(verifier.Bytes())
+	gas, err := hash_proof.EstimateVerifyGas(1, 0)
+	if err != nil {
+		t.Fatalf("Failed to estimate gas: %v", err)
+	}
+
+	if m.Curve != "bn254" || m.CircuitHash != circuitHash || m.VKFingerprint != vkFingerprint ||
+		m.VerifierFingerprint != hex.EncodeToString(verifierHash[:]) ||
+		m.Constraints != ccs.GetNbConstraints() || m.PublicInputs != 1 || m.Commitments != 0 || m.EstimatedGas != gas {
+		t.Fatalf("Unexpected deployment manifest: %+v", m)
+	}
+	if !strings.Contains(verifier.String(), "pragma solidity "+m.SolidityPragma+";") {
+		t.Fatalf("Pragma %q does not match the exported verifier", m.SolidityPragma)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Failed to marshal deployment manifest: %v", err)
+	}
+	var decoded hash_proof.DeploymentManifest
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != m {
+		t.Fatalf("Deployment manifest does not round-trip through JSON: %v", err)
+	}
+
+	if _, err := hash_proof.GenerateDeploymentManifest(vk, ccs, ecc.BLS12_381); err == nil {
+		t.Fatal("Expected an error for a curve mismatch")
+	}
+	blsCCS, _, blsVK, _, _ := testutil.GenerateFixture(t, ecc.BLS12_381)
+	if _, err := hash_proof.GenerateDeploymentManifest(blsVK, blsCCS, ecc.BLS12_381); err == nil {
+		t.Fatal("Expected an error for a curve without a Solidity verifier")
+	}
+}
+
+func TestGenerateDeploymentManifestCommitments(t *testing.T) {
+	// CoprimeHashCircuit uses rangecheck, so it has one BSB22 commitment.
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hash_proof.CoprimeHashCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	_, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to run setup: %v", err)
+	}
+
+	m, err := hash_proof.GenerateDeploymentManifest(vk, ccs, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to generate deployment manifest: %v", err)
+	}
+	nbPublic := ccs.GetNbPublicVariables() - 1
+	gas, err := hash_proof.EstimateVerifyGas(nbPublic, 1)
+	if err != nil {
+		t.Fatalf("Failed to estimate gas: %v", err)
+	}
+	if m.PublicInputs != nbPublic || m.Commitments != 1 || m.EstimatedGas != gas {
+		t.Fatalf("Unexpected deployment manifest: %+v", m)
+	}
+	// The proof, the commitment, its proof of knowledge and the inputs.
+	if want := 4 + 32*(8+2+2+nbPublic); m.EstimatedGas.CalldataBytes != want {
+		t.Fatalf("Estimated calldata is %d bytes, verifyProof takes %d", m.EstimatedGas.CalldataBytes, want)
+	}
+}
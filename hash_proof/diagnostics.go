@@ -0,0 +1,183 @@
+package hash_proof
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Redacted replaces every representation of a secret in diagnostics.
+const Redacted = "[REDACTED]"
+
+// DiagnosticsOptions configures ExportDiagnostics.
+type DiagnosticsOptions struct {
+	// Curve is the curve the proof was attempted on; BN254 by default.
+	Curve ecc.ID
+	// CCS, if set, is the compiled circuit whose constraint stats are
+	// included.
+	CCS constraint.ConstraintSystem
+}
+
+// DiagnosticsBundle describes a failed proof for a support ticket. It holds
+// no secret input: the error is redacted and only public inputs are kept.
+type DiagnosticsBundle struct {
+	Circuit      string            `json:"circuit"`
+	Curve        string            `json:"curve"`
+	GnarkVersion string            `json:"gnarkVersion"`
+	Error        string            `json:"error"`
+	Constraints  int               `json:"constraints,omitempty"`
+	NbPublic     int               `json:"nbPublic,omitempty"`
+	NbSecret     int               `json:"nbSecret,omitempty"`
+	PublicInputs map[string]string `json:"publicInputs"`
+}
+
+// ExportDiagnostics captures the failure err of proving assignment. Every
+// secret value of the assignment is scrubbed from the error message in its
+// decimal, hexadecimal and base64 forms, wherever it appears as a whole
+// token. Its 32-byte hexadecimal word, the form it takes in calldata, is
+// scrubbed even inside a longer hexadecimal run. A secret equal to a public
+// input is still redacted from the message but kept in PublicInputs, since
+// it is public anyway.
+func ExportDiagnostics(err error, assignment frontend.Circuit, opts DiagnosticsOptions) (*DiagnosticsBundle, error) {
+	if err == nil {
+		return nil, fmt.Errorf("no failure to report")
+	}
+	curveID := opts.Curve
+	if curveID == ecc.UNKNOWN {
+		curveID = ecc.BN254
+	}
+
+	full, wErr := frontend.NewWitness(assignment, curveID.ScalarField())
+	if wErr != nil {
+		return nil, fmt.Errorf("cannot read assignment: %w", wErr)
+	}
+	elems, nbPublic, wErr := witnessElements(full)
+	if wErr != nil {
+		return nil, wErr
+	}
+	pub, wErr := full.Public()
+	if wErr != nil {
+		return nil, wErr
+	}
+	inputs, wErr := DescribePublicWitness(assignment, pub, curveID)
+	if wErr != nil {
+		return nil, wErr
+	}
+
+	d := &DiagnosticsBundle{
+		Circuit:      CircuitName(assignment),
+		Curve:        curveID.String(),
+		GnarkVersion: gnarkVersion(),
+		Error:        redactSecrets(err.Error(), elems[nbPublic:]),
+		PublicInputs: inputs,
+	}
+	if opts.CCS != nil {
+		d.Constraints = opts.CCS.GetNbConstraints()
+		d.NbPublic = opts.CCS.GetNbPublicVariables() - 1
+		d.NbSecret = opts.CCS.GetNbSecretVariables()
+	}
+	return d, nil
+}
+
+// SaveDiagnostics atomically writes the ExportDiagnostics bundle of err to
+// path as indented JSON.
+func SaveDiagnostics(path string, err error, assignment frontend.Circuit, opts DiagnosticsOptions) error {
+	d, dErr := ExportDiagnostics(err, assignment, opts)
+	if dErr != nil {
+		return dErr
+	}
+	data, dErr := json.MarshalIndent(d, "", "  ")
+	if dErr != nil {
+		return dErr
+	}
+	return WriteFileAtomic(path, append(data, '\n'), 0644)
+}
+
+// secretEncodings returns the forms a secret may take in an error message.
+func secretEncodings(v *big.Int) []string {
+	h := v.Text(16)
+	word := v.FillBytes(make([]byte, 32))
+	return []string{
+		v.String(),
+		h, strings.ToUpper(h),
+		"0x" + h, "0x" + strings.ToUpper(h),
+		base64.StdEncoding.EncodeToString(v.Bytes()),
+		base64.RawStdEncoding.EncodeToString(v.Bytes()),
+		base64.StdEncoding.EncodeToString(word),
+		base64.RawStdEncoding.EncodeToString(word),
+	}
+}
+
+// secretWords returns the secret as a zero-padded 32-byte hexadecimal word.
+// Words are long enough to be redacted wherever they occur, such as inside
+// a calldata blob, without matching unrelated text.
+func secretWords(v *big.Int) []string {
+	h := fmt.Sprintf("%064x", v)
+	return []string{h, strings.ToUpper(h)}
+}
+
+func redactSecrets(s string, secrets []*big.Int) string {
+	var encodings []string
+	for _, v := range secrets {
+		for _, w := range secretWords(v) {
+			s = strings.ReplaceAll(s, w, Redacted)
+		}
+		encodings = append(encodings, secretEncodings(v)...)
+	}
+	// Longest first, so no shorter form leaves part of a longer one behind.
+	sort.SliceStable(encodings, func(i, j int) bool { return len(encodings[i]) > len(encodings[j]) })
+	for _, e := range encodings {
+		if e != "" {
+			s = redactToken(s, e)
+		}
+	}
+	return s
+}
+
+// redactToken replaces the occurrences of tok in s that are not part of a
+// longer alphanumeric token, so the secret 35 is redacted from
+// "35 == 42" but not from "1350".
+func redactToken(s, tok string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, tok)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(tok)
+		if (i > 0 && isTokenByte(s[i-1])) || (end < len(s) && isTokenByte(s[end])) {
+			b.WriteString(s[:i+1])
+			s = s[i+1:]
+			continue
+		}
+		b.WriteString(s[:i])
+		b.WriteString(Redacted)
+		s = s[end:]
+	}
+}
+
+func isTokenByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func gnarkVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/consensys/gnark" {
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,127 @@
+package hash_proof
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestExportDiagnostics(t *testing.T) {
+	// 100 - 35 is not 64, so the solver reports the secret 64.
+	a, b, difference := big.NewInt(100), big.NewInt(35), big.NewInt(64)
+	assignment := &DifferenceCircuit{A: a, B: b, Difference: difference}
+	for _, c := range []struct {
+		dst      *frontend.Variable
+		preImage *big.Int
+	}{{&assignment.HashA, a}, {&assignment.HashB, b}, {&assignment.DifferenceHash, difference}} {
+		h, err := MiMCHash(ecc.BN254, c.preImage)
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		*c.dst = h
+	}
+
+	solveErr := test.IsSolved(&DifferenceCircuit{}, assignment, ecc.BN254.ScalarField())
+	if solveErr == nil || !strings.Contains(solveErr.Error(), " 64 ") {
+		t.Fatalf("Expected the solver error to contain the secret: %v", solveErr)
+	}
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &DifferenceCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+
+	word := make([]byte, 32)
+	failure := fmt.Errorf("%w; A=%d B=0x%x (0x%X) in %s, %s, %s", solveErr, a, b, b,
+		base64.StdEncoding.EncodeToString(a.Bytes()),
+		base64.StdEncoding.EncodeToString(difference.FillBytes(word)),
+		base64.RawStdEncoding.EncodeToString(b.Bytes()))
+
+	d, err := ExportDiagnostics(failure, assignment, DiagnosticsOptions{CCS: ccs})
+	if err != nil {
+		t.Fatalf("Failed to export diagnostics: %v", err)
+	}
+
+	if !strings.HasPrefix(d.Error, "[assertIsEqual] "+Redacted+" == 65") ||
+		!strings.HasSuffix(d.Error, "; A="+Redacted+" B="+Redacted+" ("+Redacted+") in "+Redacted+", "+Redacted+", "+Redacted) {
+		t.Fatalf("Secrets were not redacted: %q", d.Error)
+	}
+	if redactSecrets(d.Error, []*big.Int{a, b, difference}) != d.Error {
+		t.Fatalf("A secret survived redaction: %q", d.Error)
+	}
+	// Only whole tokens are redacted.
+	if !strings.Contains(d.Error, "difference_circuit.go:") {
+		t.Fatalf("Redaction removed more than the secrets: %q", d.Error)
+	}
+
+	if d.Circuit != "DifferenceCircuit" || d.Curve != "bn254" || d.GnarkVersion == "" ||
+		d.Constraints != ccs.GetNbConstraints() || d.NbPublic != 3 || d.NbSecret != 3 {
+		t.Fatalf("Unexpected diagnostics: %+v", d)
+	}
+	expectedInputs := map[string]string{
+		"HashA":          fmt.Sprint(assignment.HashA),
+		"HashB":          fmt.Sprint(assignment.HashB),
+		"DifferenceHash": fmt.Sprint(assignment.DifferenceHash),
+	}
+	if !reflect.DeepEqual(d.PublicInputs, expectedInputs) {
+		t.Fatalf("Public inputs did not survive: got %v, want %v", d.PublicInputs, expectedInputs)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Failed to marshal diagnostics: %v", err)
+	}
+	var decoded DiagnosticsBundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal diagnostics: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, d) {
+		t.Fatalf("Diagnostics do not round-trip through JSON: got %+v, want %+v", decoded, d)
+	}
+
+	// Calldata words are redacted whether zero-padded on their own or inside
+	// a longer hex run.
+	padded := fmt.Sprintf("%064x", difference)
+	calldata := "0x" + strings.Repeat("ab", 36) + padded + strings.Repeat("cd", 32)
+	d, err = ExportDiagnostics(fmt.Errorf("word 0x%s, upper %X, calldata %s", padded, padded, calldata), assignment, DiagnosticsOptions{})
+	if err != nil {
+		t.Fatalf("Failed to export diagnostics: %v", err)
+	}
+	if strings.Contains(strings.ToLower(d.Error), padded) {
+		t.Fatalf("A padded hex secret survived redaction: %q", d.Error)
+	}
+	if want := "0x" + strings.Repeat("ab", 36) + Redacted + strings.Repeat("cd", 32); !strings.HasSuffix(d.Error, want) {
+		t.Fatalf("Calldata was not redacted word by word: %q", d.Error)
+	}
+
+	path := filepath.Join(t.TempDir(), "diagnostics.json")
+	if err := SaveDiagnostics(path, failure, assignment, DiagnosticsOptions{CCS: ccs}); err != nil {
+		t.Fatalf("Failed to save diagnostics: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read diagnostics: %v", err)
+	}
+	var saved DiagnosticsBundle
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Failed to unmarshal saved diagnostics: %v", err)
+	}
+	if saved.Circuit != "DifferenceCircuit" || saved.Constraints != ccs.GetNbConstraints() || strings.Contains(string(data), " 64 ") {
+		t.Fatalf("Unexpected saved diagnostics: %s", data)
+	}
+
+	if _, err := ExportDiagnostics(nil, assignment, DiagnosticsOptions{}); err == nil {
+		t.Fatal("Expected an error without a failure")
+	}
+}
@@ -0,0 +1,39 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// DifferenceCircuit proves that the secret Difference equals A - B, where A,
+// B and Difference are each committed by a public MiMC hash. The subtraction
+// is in the field: when B > A as integers, Difference is p - (B - A) for the
+// scalar field modulus p rather than a negative number, and proves as such.
+// Callers that need a non-negative integer difference must range-check it.
+type DifferenceCircuit struct {
+	A              frontend.Variable `gnark:",secret"`
+	B              frontend.Variable `gnark:",secret"`
+	Difference     frontend.Variable `gnark:",secret"`
+	HashA          frontend.Variable `gnark:",public"`
+	HashB          frontend.Variable `gnark:",public"`
+	DifferenceHash frontend.Variable `gnark:",public"`
+}
+
+func (circuit *DifferenceCircuit) Define(api frontend.API) error {
+	for _, c := range []struct{ preImage, hash frontend.Variable }{
+		{circuit.A, circuit.HashA},
+		{circuit.B, circuit.HashB},
+		{circuit.Difference, circuit.DifferenceHash},
+	} {
+		hFunc, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		hFunc.Write(c.preImage)
+		api.AssertIsEqual(c.hash, hFunc.Sum())
+	}
+
+	api.AssertIsEqual(circuit.Difference, api.Sub(circuit.A, circuit.B))
+
+	return nil
+}
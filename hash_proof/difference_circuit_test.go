@@ -0,0 +1,38 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestDifferenceCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit DifferenceCircuit
+
+	assignment := func(a, b, difference *big.Int) *DifferenceCircuit {
+		hashes := make([]*big.Int, 3)
+		for i, v := range []*big.Int{a, b, difference} {
+			h, err := MiMCHash(ecc.BN254, v)
+			if err != nil {
+				t.Fatalf("Failed to compute MiMC hash: %v", err)
+			}
+			hashes[i] = h
+		}
+		return &DifferenceCircuit{
+			A: a, B: b, Difference: difference,
+			HashA: hashes[0], HashB: hashes[1], DifferenceHash: hashes[2],
+		}
+	}
+
+	assert.ProverSucceeded(&circuit, assignment(big.NewInt(100), big.NewInt(35), big.NewInt(65)), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(big.NewInt(100), big.NewInt(35), big.NewInt(64)), test.WithCurves(ecc.BN254))
+
+	// 35 - 100 wraps around to p - 65, so claiming 65 fails.
+	wrapped := new(big.Int).Sub(ecc.BN254.ScalarField(), big.NewInt(65))
+	assert.ProverSucceeded(&circuit, assignment(big.NewInt(35), big.NewInt(100), wrapped), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(big.NewInt(35), big.NewInt(100), big.NewInt(65)), test.WithCurves(ecc.BN254))
+}
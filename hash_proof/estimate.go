@@ -0,0 +1,375 @@
+package hash_proof
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// Calibration circuits are chains of multiplications measured at these
+// lengths. Groth16 work and key material grow with the number of constraints
+// n (MSMs over the wires) and with the FFT domain size d, the next power of
+// two above n, so each quantity is modelled as
+//
+//	value(n, d) = k + a*n + b*d
+//
+// and k, a, b are fitted to the samples by least squares. The lengths are
+// chosen so that some samples share a domain and others do not.
+var calibrationSizes = [5]int{300, 500, 900, 1500, 2500}
+
+// Confidence bounds applied around each expected value. In a multiplication
+// chain every constraint introduces a fresh wire, which makes the calibration
+// an upper bound for circuits that reuse wires, hence the wide lower bound on
+// sizes. Timings also depend on the machine and its load; allocations depend
+// only on the code paths taken, so their interval is tighter.
+const (
+	sizeLowFactor   = 0.6
+	sizeHighFactor  = 1.25
+	timeLowFactor   = 0.5
+	timeHighFactor  = 2
+	allocLowFactor  = 0.75
+	allocHighFactor = 1.5
+)
+
+// Bound is an estimated quantity with its confidence interval.
+type Bound struct {
+	Expected float64 `json:"expected"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+}
+
+// Estimate predicts the resources Setup and Prove will need for a circuit.
+type Estimate struct {
+	Curve             string `json:"curve"`
+	Constraints       int    `json:"constraints"`
+	PublicVariables   int    `json:"publicVariables"`
+	ProvingKeyBytes   Bound  `json:"provingKeyBytes"`
+	VerifyingKeyBytes Bound  `json:"verifyingKeyBytes"`
+	SetupSeconds      Bound  `json:"setupSeconds"`
+	ProveSeconds      Bound  `json:"proveSeconds"`
+	SetupAllocBytes   Bound  `json:"setupAllocBytes"`
+	ProveAllocBytes   Bound  `json:"proveAllocBytes"`
+}
+
+// CalibrationSample is one measured Setup and Prove run.
+type CalibrationSample struct {
+	Constraints       int   `json:"constraints"`
+	PublicVariables   int   `json:"publicVariables"`
+	ProvingKeyBytes   int64 `json:"provingKeyBytes"`
+	VerifyingKeyBytes int64 `json:"verifyingKeyBytes"`
+	SetupNanos        int64 `json:"setupNanos"`
+	ProveNanos        int64 `json:"proveNanos"`
+	SetupAllocBytes   int64 `json:"setupAllocBytes"`
+	ProveAllocBytes   int64 `json:"proveAllocBytes"`
+}
+
+// Calibration holds the measurements estimates are extrapolated from.
+type Calibration struct {
+	Curve   string                                   `json:"curve"`
+	GoArch  string                                   `json:"goarch"`
+	NumCPU  int                                      `json:"numCPU"`
+	Samples [len(calibrationSizes)]CalibrationSample `json:"samples"`
+}
+
+type calibrationCircuit struct {
+	X frontend.Variable `gnark:",secret"`
+	Y frontend.Variable `gnark:",public"`
+
+	n int
+}
+
+func (circuit *calibrationCircuit) Define(api frontend.API) error {
+	x := circuit.X
+	for i := 0; i < circuit.n; i++ {
+		x = api.Mul(x, x)
+	}
+	api.AssertIsDifferent(x, circuit.Y)
+	return nil
+}
+
+// Calibrate measures Setup and Prove on synthetic circuits of known size.
+func Calibrate(curveID ecc.ID) (*Calibration, error) {
+	c := &Calibration{
+		Curve:  curveID.String(),
+		GoArch: runtime.GOARCH,
+		NumCPU: runtime.NumCPU(),
+	}
+	for i, n := range calibrationSizes {
+		sample, err := measure(curveID, n)
+		if err != nil {
+			return nil, err
+		}
+		c.Samples[i] = sample
+	}
+	return c, nil
+}
+
+func measure(curveID ecc.ID, n int) (CalibrationSample, error) {
+	circuit := &calibrationCircuit{n: n}
+	ccs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return CalibrationSample{}, err
+	}
+
+	sample := CalibrationSample{
+		Constraints:     ccs.GetNbConstraints(),
+		PublicVariables: ccs.GetNbPublicVariables(),
+	}
+
+	var pk groth16.ProvingKey
+	var vk groth16.VerifyingKey
+	sample.SetupNanos, sample.SetupAllocBytes, err = measureRun(func() (err error) {
+		pk, vk, err = groth16.Setup(ccs)
+		return err
+	})
+	if err != nil {
+		return CalibrationSample{}, err
+	}
+
+	if sample.ProvingKeyBytes, err = pk.WriteRawTo(io.Discard); err != nil {
+		return CalibrationSample{}, err
+	}
+	if sample.VerifyingKeyBytes, err = vk.WriteRawTo(io.Discard); err != nil {
+		return CalibrationSample{}, err
+	}
+
+	witness, err := frontend.NewWitness(&calibrationCircuit{X: 3, Y: 0, n: n}, curveID.ScalarField())
+	if err != nil {
+		return CalibrationSample{}, err
+	}
+	sample.ProveNanos, sample.ProveAllocBytes, err = measureRun(func() error {
+		_, err := groth16.Prove(ccs, pk, witness)
+		return err
+	})
+	if err != nil {
+		return CalibrationSample{}, err
+	}
+
+	return sample, nil
+}
+
+func measureRun(f func() error) (nanos, alloc int64, err error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	err = f()
+	nanos = time.Since(start).Nanoseconds()
+	runtime.ReadMemStats(&after)
+	return nanos, int64(after.TotalAlloc - before.TotalAlloc), err
+}
+
+// LoadOrCalibrate reads a persisted calibration for curveID from path, or
+// measures one and writes it there so repeated dry-runs are fast. A file
+// measured on another architecture or CPU count is measured again.
+func LoadOrCalibrate(path string, curveID ecc.ID) (*Calibration, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var c Calibration
+		if err := json.Unmarshal(data, &c); err == nil && c.Curve == curveID.String() && c.GoArch == runtime.GOARCH && c.NumCPU == runtime.NumCPU() && c.complete() {
+			return &c, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	c, err := Calibrate(curveID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := WriteFileAtomic(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// complete reports whether every calibration size was measured, which a file
+// written with fewer sizes is not.
+func (c *Calibration) complete() bool {
+	for i, s := range c.Samples {
+		if s.Constraints < calibrationSizes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultCalibrationPath is where the generator persists calibration data.
+func DefaultCalibrationPath(curveID ecc.ID) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "hash_proof", fmt.Sprintf("calibration-%s.json", curveID))
+}
+
+var (
+	calibrationMu    sync.Mutex
+	calibrationCache = map[string]*Calibration{}
+)
+
+// EstimateSetup predicts Groth16 artifact sizes, timings and allocations for
+// ccs without generating any keys. The calibration is read from
+// DefaultCalibrationPath, or measured and persisted there, once per path and
+// process.
+func EstimateSetup(ccs constraint.ConstraintSystem) (Estimate, error) {
+	curveID, err := curveOf(ccs)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	path := DefaultCalibrationPath(curveID)
+	calibrationMu.Lock()
+	c, ok := calibrationCache[path]
+	if !ok {
+		c, err = LoadOrCalibrate(path, curveID)
+		if err != nil {
+			calibrationMu.Unlock()
+			return Estimate{}, err
+		}
+		calibrationCache[path] = c
+	}
+	calibrationMu.Unlock()
+
+	return c.Estimate(ccs)
+}
+
+// Estimate extrapolates the calibration to ccs.
+func (c *Calibration) Estimate(ccs constraint.ConstraintSystem) (Estimate, error) {
+	curveID, err := curveOf(ccs)
+	if err != nil {
+		return Estimate{}, err
+	}
+	if curveID.String() != c.Curve {
+		return Estimate{}, fmt.Errorf("calibration is for %s, circuit is over %s", c.Curve, curveID)
+	}
+
+	n := ccs.GetNbConstraints()
+	nbPublic := ccs.GetNbPublicVariables()
+	model := newLinearModel(c.Samples)
+	fit := func(y func(CalibrationSample) int64) float64 {
+		return max(model.predict(y, n), model.floor(y, n))
+	}
+
+	// The verifying key holds one compressed G1 point per public variable,
+	// independently of the constraint count.
+	g1Bytes := float64((curveID.BaseField().BitLen() + 7) / 8)
+	s0 := c.Samples[0]
+	vkBytes := float64(s0.VerifyingKeyBytes) + g1Bytes*float64(nbPublic-s0.PublicVariables)
+
+	return Estimate{
+		Curve:             curveID.String(),
+		Constraints:       n,
+		PublicVariables:   nbPublic,
+		ProvingKeyBytes:   bound(fit(func(s CalibrationSample) int64 { return s.ProvingKeyBytes }), sizeLowFactor, sizeHighFactor),
+		VerifyingKeyBytes: bound(vkBytes, sizeLowFactor, sizeHighFactor),
+		SetupSeconds:      bound(fit(func(s CalibrationSample) int64 { return s.SetupNanos })/1e9, timeLowFactor, timeHighFactor),
+		ProveSeconds:      bound(fit(func(s CalibrationSample) int64 { return s.ProveNanos })/1e9, timeLowFactor, timeHighFactor),
+		SetupAllocBytes:   bound(fit(func(s CalibrationSample) int64 { return s.SetupAllocBytes }), allocLowFactor, allocHighFactor),
+		ProveAllocBytes:   bound(fit(func(s CalibrationSample) int64 { return s.ProveAllocBytes }), allocLowFactor, allocHighFactor),
+	}, nil
+}
+
+// linearModel fits value = k + a*n + b*d to the calibration samples by least
+// squares, solving the normal equations with Cramer's rule.
+type linearModel struct {
+	samples [len(calibrationSizes)]CalibrationSample
+	rows    [len(calibrationSizes)][3]float64
+	normal  [3][3]float64
+	det     float64
+}
+
+func newLinearModel(samples [len(calibrationSizes)]CalibrationSample) linearModel {
+	m := linearModel{samples: samples}
+	for i, s := range samples {
+		m.rows[i] = [3]float64{1, float64(s.Constraints), float64(domainSize(s.Constraints))}
+	}
+	for i := range m.normal {
+		for j := range m.normal[i] {
+			for _, row := range m.rows {
+				m.normal[i][j] += row[i] * row[j]
+			}
+		}
+	}
+	m.det = det3(m.normal)
+	return m
+}
+
+func (m linearModel) predict(y func(CalibrationSample) int64, n int) float64 {
+	var rhs [3]float64
+	for i := range rhs {
+		for r, s := range m.samples {
+			rhs[i] += m.rows[r][i] * float64(y(s))
+		}
+	}
+
+	var coeffs [3]float64
+	for col := range coeffs {
+		normal := m.normal
+		for i := range normal {
+			normal[i][col] = rhs[i]
+		}
+		coeffs[col] = det3(normal) / m.det
+	}
+	return coeffs[0] + coeffs[1]*float64(n) + coeffs[2]*float64(domainSize(n))
+}
+
+// floor is the lowest per-constraint value among the samples, scaled to n
+// constraints. It keeps a noisy fit from extrapolating to zero or below.
+func (m linearModel) floor(y func(CalibrationSample) int64, n int) float64 {
+	lowest := -1.0
+	for _, s := range m.samples {
+		perConstraint := float64(y(s)) / float64(s.Constraints)
+		if lowest < 0 || perConstraint < lowest {
+			lowest = perConstraint
+		}
+	}
+	return lowest * float64(n)
+}
+
+func det3(r [3][3]float64) float64 {
+	return r[0][0]*(r[1][1]*r[2][2]-r[1][2]*r[2][1]) -
+		r[0][1]*(r[1][0]*r[2][2]-r[1][2]*r[2][0]) +
+		r[0][2]*(r[1][0]*r[2][1]-r[1][1]*r[2][0])
+}
+
+func domainSize(n int) int {
+	return int(ecc.NextPowerOfTwo(uint64(n)))
+}
+
+func bound(expected, low, high float64) Bound {
+	return Bound{Expected: expected, Low: expected * low, High: expected * high}
+}
+
+func curveOf(ccs constraint.ConstraintSystem) (ecc.ID, error) {
+	return curveOfField(ccs.Field())
+}
+
+func curveOfField(field *big.Int) (ecc.ID, error) {
+	for _, id := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_761, ecc.BW6_633} {
+		if id.ScalarField().Cmp(field) == 0 {
+			return id, nil
+		}
+	}
+	return ecc.UNKNOWN, fmt.Errorf("no curve with scalar field %s", field)
+}
@@ -0,0 +1,99 @@
+package hash_proof
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestEstimateSetup(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var circuit HashCircuit
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+
+	estimate, err := EstimateSetup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to estimate setup: %v", err)
+	}
+	t.Logf("Estimate: %+v", estimate)
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+
+	pkSize, err := pk.WriteRawTo(io.Discard)
+	if err != nil {
+		t.Fatalf("Failed to serialize proving key: %v", err)
+	}
+	vkSize, err := vk.WriteRawTo(io.Discard)
+	if err != nil {
+		t.Fatalf("Failed to serialize verifying key: %v", err)
+	}
+
+	if f := float64(pkSize); f < estimate.ProvingKeyBytes.Low || f > estimate.ProvingKeyBytes.High {
+		t.Fatalf("Proving key size %d outside estimate %+v", pkSize, estimate.ProvingKeyBytes)
+	}
+	if f := float64(vkSize); f < estimate.VerifyingKeyBytes.Low || f > estimate.VerifyingKeyBytes.High {
+		t.Fatalf("Verifying key size %d outside estimate %+v", vkSize, estimate.VerifyingKeyBytes)
+	}
+	if estimate.SetupSeconds.Expected <= 0 || estimate.ProveSeconds.Expected <= 0 {
+		t.Fatalf("Timing estimates must be positive: %+v", estimate)
+	}
+	if estimate.SetupAllocBytes.Expected <= 0 || estimate.ProveAllocBytes.Expected <= 0 {
+		t.Fatalf("Allocation estimates must be positive: %+v", estimate)
+	}
+	if _, err := os.Stat(DefaultCalibrationPath(ecc.BN254)); err != nil {
+		t.Fatalf("EstimateSetup did not persist its calibration: %v", err)
+	}
+}
+
+func TestCalibrationPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+
+	first, err := LoadOrCalibrate(path, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to calibrate: %v", err)
+	}
+
+	second, err := LoadOrCalibrate(path, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to load calibration: %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("Calibration did not round-trip: %+v != %+v", first, second)
+	}
+
+	// A calibration from a machine with another CPU count is measured again.
+	other := *first
+	other.NumCPU = runtime.NumCPU() + 1
+	data, err := json.Marshal(other)
+	if err != nil {
+		t.Fatalf("Failed to encode calibration: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write calibration: %v", err)
+	}
+	third, err := LoadOrCalibrate(path, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to recalibrate: %v", err)
+	}
+	if third.NumCPU != runtime.NumCPU() {
+		t.Fatalf("Loaded a calibration for %d CPUs on %d", third.NumCPU, runtime.NumCPU())
+	}
+}
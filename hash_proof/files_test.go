@@ -0,0 +1,67 @@
+package hash_proof
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "HashProofVerifier.sol")
+
+	err := WriteFileAtomic(path, []byte("contract Verifier {}"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "contract Verifier {}" {
+		t.Fatalf("Unexpected content: %q", data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Fatalf("Unexpected permissions: %v", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Temporary files left behind: %v", entries)
+	}
+}
+
+func TestWriteAtomicFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "remix_proof_values.json")
+
+	errInterrupted := errors.New("interrupted")
+	err := WriteAtomic(path, 0644, func(w io.Writer) error {
+		if _, err := w.Write([]byte(`{"proof": [`)); err != nil {
+			return err
+		}
+		return errInterrupted
+	})
+	if !errors.Is(err, errInterrupted) {
+		t.Fatalf("Expected the write error, got %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Interrupted write left files behind: %v", entries)
+	}
+}
@@ -0,0 +1,138 @@
+package hash_proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// ForestMembershipCircuit proves that MiMC(PreImage) is a leaf of one of the
+// trees whose roots are public, without revealing which. The number of roots
+// and the tree depth are fixed by NewForestMembershipCircuit.
+type ForestMembershipCircuit struct {
+	PreImage    frontend.Variable   `gnark:",secret"`
+	Siblings    []frontend.Variable `gnark:",secret"`
+	PathIndices []frontend.Variable `gnark:",secret"`
+	Roots       []frontend.Variable `gnark:",public"`
+}
+
+// NewForestMembershipCircuit returns a circuit definition for nbRoots trees
+// of the given depth.
+func NewForestMembershipCircuit(nbRoots, depth int) *ForestMembershipCircuit {
+	return &ForestMembershipCircuit{
+		Siblings:    make([]frontend.Variable, depth),
+		PathIndices: make([]frontend.Variable, depth),
+		Roots:       make([]frontend.Variable, nbRoots),
+	}
+}
+
+func (circuit *ForestMembershipCircuit) Define(api frontend.API) error {
+	if len(circuit.Roots) == 0 || len(circuit.Siblings) == 0 || len(circuit.PathIndices) != len(circuit.Siblings) {
+		return errors.New("forest circuit must be created with NewForestMembershipCircuit")
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	node, err := merkleRoot(api, hFunc.Sum(), circuit.Siblings, circuit.PathIndices)
+	if err != nil {
+		return err
+	}
+
+	// The candidate root equals some public root iff the product of the
+	// differences vanishes.
+	product := frontend.Variable(1)
+	for _, root := range circuit.Roots {
+		product = api.Mul(product, api.Sub(node, root))
+	}
+	api.AssertIsEqual(product, 0)
+
+	return nil
+}
+
+// MerkleForest is the native set of trees a ForestMembershipCircuit proves
+// membership in. Every tree has the same depth and unused leaves are zero.
+type MerkleForest struct {
+	curveID ecc.ID
+	depth   int
+	trees   [][][]*big.Int
+}
+
+// NewMerkleForest builds one tree of the given depth per entry of members,
+// whose leaves are the MiMC hashes of that entry's secrets.
+func NewMerkleForest(curveID ecc.ID, depth int, members [][]*big.Int) (*MerkleForest, error) {
+	if depth < 1 || depth > 30 {
+		return nil, fmt.Errorf("unsupported tree depth %d", depth)
+	}
+
+	f := &MerkleForest{curveID: curveID, depth: depth}
+	for t, secrets := range members {
+		if len(secrets) > 1<<depth {
+			return nil, fmt.Errorf("tree %d has %d members, more than %d leaves", t, len(secrets), 1<<depth)
+		}
+
+		leaves := make([]*big.Int, 1<<depth)
+		for i := range leaves {
+			leaves[i] = new(big.Int)
+		}
+		for i, s := range secrets {
+			leaf, err := MiMCHash(curveID, s)
+			if err != nil {
+				return nil, err
+			}
+			leaves[i] = leaf
+		}
+
+		levels, err := merkleLevelsFromLeaves(curveID, leaves)
+		if err != nil {
+			return nil, err
+		}
+		f.trees = append(f.trees, levels)
+	}
+	return f, nil
+}
+
+// Roots returns the root of every tree, in the order the trees were given.
+func (f *MerkleForest) Roots() []*big.Int {
+	roots := make([]*big.Int, len(f.trees))
+	for i, levels := range f.trees {
+		roots[i] = levels[f.depth][0]
+	}
+	return roots
+}
+
+// Assignment finds the tree holding secret and fills a
+// ForestMembershipCircuit assignment with its path.
+func (f *MerkleForest) Assignment(secret *big.Int) (*ForestMembershipCircuit, error) {
+	leaf, err := MiMCHash(f.curveID, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, levels := range f.trees {
+		for index, l := range levels[0] {
+			if l.Cmp(leaf) != 0 {
+				continue
+			}
+
+			assignment := NewForestMembershipCircuit(len(f.trees), f.depth)
+			assignment.PreImage = secret
+			for i := 0; i < f.depth; i, index = i+1, index>>1 {
+				assignment.Siblings[i] = levels[i][index^1]
+				assignment.PathIndices[i] = index & 1
+			}
+			for i, root := range f.Roots() {
+				assignment.Roots[i] = root
+			}
+			return assignment, nil
+		}
+	}
+	return nil, errors.New("secret is not a member of any tree")
+}
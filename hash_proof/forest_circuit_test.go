@@ -0,0 +1,57 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestForestMembershipCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const nbRoots, depth = 3, 3
+	members := [][]*big.Int{
+		{big.NewInt(1), big.NewInt(2)},
+		{big.NewInt(10), big.NewInt(11), big.NewInt(12)},
+		{big.NewInt(20), big.NewInt(35)},
+	}
+	forest, err := NewMerkleForest(ecc.BN254, depth, members)
+	if err != nil {
+		t.Fatalf("Failed to build forest: %v", err)
+	}
+
+	circuit := NewForestMembershipCircuit(nbRoots, depth)
+	for _, secret := range []int64{1, 35} {
+		assignment, err := forest.Assignment(big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to build assignment for %d: %v", secret, err)
+		}
+		assert.ProverSucceeded(circuit, assignment, test.WithCurves(ecc.BN254))
+	}
+
+	if _, err := forest.Assignment(big.NewInt(99)); err == nil {
+		t.Fatal("Expected a secret in no tree to be rejected by the builder")
+	}
+
+	// Reuse a valid path with a secret that is in no tree.
+	outsider, err := forest.Assignment(big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+	outsider.PreImage = 99
+	assert.ProverFailed(circuit, outsider, test.WithCurves(ecc.BN254))
+}
+
+func TestForestMembershipCircuitConstraints(t *testing.T) {
+	for _, nbRoots := range []int{1, 12} {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, NewForestMembershipCircuit(nbRoots, 20))
+		if err != nil {
+			t.Fatalf("Failed to compile circuit: %v", err)
+		}
+		t.Logf("ForestMembershipCircuit N=%d depth=20 constraints: %d", nbRoots, ccs.GetNbConstraints())
+	}
+}
@@ -0,0 +1,160 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	stdhash "github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+
+	poseidon2bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/poseidon2"
+	poseidon2bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/poseidon2"
+	poseidon2bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+	poseidon2bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/poseidon2"
+)
+
+// HashGadget selects the hash function of a generic circuit such as
+// HashCircuitOf. Implementations are empty structs usable as their zero
+// value, so the gadget is fixed by the type parameter alone.
+type HashGadget interface {
+	// Name identifies the gadget in circuit names.
+	Name() string
+	// New returns the in-circuit hasher.
+	New(api frontend.API) (stdhash.FieldHasher, error)
+	// Native returns the matching out-of-circuit hash for curveID.
+	Native(curveID ecc.ID) (hash.Hash, bool)
+}
+
+// MiMCGadget is the MiMC hash every circuit of this package uses by default.
+type MiMCGadget struct{}
+
+func (MiMCGadget) Name() string { return "MiMC" }
+
+func (MiMCGadget) New(api frontend.API) (stdhash.FieldHasher, error) { return mimc.New(api) }
+
+func (MiMCGadget) Native(curveID ecc.ID) (hash.Hash, bool) {
+	h, ok := mimcByCurve[curveID]
+	return h, ok
+}
+
+var poseidon2ByCurve = map[ecc.ID]hash.Hash{
+	ecc.BN254:     hash.POSEIDON2_BN254,
+	ecc.BLS12_381: hash.POSEIDON2_BLS12_381,
+	ecc.BLS12_377: hash.POSEIDON2_BLS12_377,
+	ecc.BW6_761:   hash.POSEIDON2_BW6_761,
+}
+
+// poseidon2Rounds are the full and partial rounds of gnark-crypto's default
+// width-2 Poseidon2 permutation for each curve. gnark's own in-circuit
+// default hasher only covers BLS12-377, so the gadget is built from these.
+var poseidon2Rounds = map[ecc.ID]func() (int, int){
+	ecc.BN254: func() (int, int) {
+		p := poseidon2bn254.GetDefaultParameters()
+		return p.NbFullRounds, p.NbPartialRounds
+	},
+	ecc.BLS12_381: func() (int, int) {
+		p := poseidon2bls12381.GetDefaultParameters()
+		return p.NbFullRounds, p.NbPartialRounds
+	},
+	ecc.BLS12_377: func() (int, int) {
+		p := poseidon2bls12377.GetDefaultParameters()
+		return p.NbFullRounds, p.NbPartialRounds
+	},
+	ecc.BW6_761: func() (int, int) {
+		p := poseidon2bw6761.GetDefaultParameters()
+		return p.NbFullRounds, p.NbPartialRounds
+	},
+}
+
+// poseidon2Curves returns the curves with Poseidon2 parameters, in
+// groth16Curves order.
+func poseidon2Curves() []ecc.ID {
+	var curves []ecc.ID
+	for _, curveID := range groth16Curves {
+		if _, ok := poseidon2Rounds[curveID]; ok {
+			curves = append(curves, curveID)
+		}
+	}
+	return curves
+}
+
+// PoseidonGadget is the Poseidon2 hash in Merkle-Damgård mode with
+// gnark-crypto's default parameters, matching its native hasher.
+type PoseidonGadget struct{}
+
+func (PoseidonGadget) Name() string { return "Poseidon2" }
+
+func (PoseidonGadget) New(api frontend.API) (stdhash.FieldHasher, error) {
+	curveID, err := curveOfField(api.Compiler().Field())
+	if err != nil {
+		return nil, err
+	}
+	rounds, ok := poseidon2Rounds[curveID]
+	if !ok {
+		return nil, fmt.Errorf("no Poseidon2 parameters for curve %s", curveID)
+	}
+	full, partial := rounds()
+	perm, err := poseidon2.NewPoseidon2FromParameters(api, 2, full, partial)
+	if err != nil {
+		return nil, err
+	}
+	return stdhash.NewMerkleDamgardHasher(api, perm, 0), nil
+}
+
+func (PoseidonGadget) Native(curveID ecc.ID) (hash.Hash, bool) {
+	h, ok := poseidon2ByCurve[curveID]
+	return h, ok
+}
+
+// circuitGadget returns the hash gadget of a generic circuit such as
+// HashCircuitOf, and MiMC for every other circuit.
+func circuitGadget(circuit frontend.Circuit) HashGadget {
+	if g, ok := circuit.(interface{ Gadget() HashGadget }); ok {
+		return g.Gadget()
+	}
+	return MiMCGadget{}
+}
+
+// GadgetHash computes out of circuit the digest gadget produces when the
+// inputs are written to it in order. MiMC digests are additionally checked
+// against the current hash suite, as in MiMCHash.
+func GadgetHash(gadget HashGadget, curveID ecc.ID, inputs ...*big.Int) (*big.Int, error) {
+	if _, ok := gadget.(MiMCGadget); ok {
+		return MiMCHash(curveID, inputs...)
+	}
+	h, ok := gadget.Native(curveID)
+	if !ok {
+		return nil, &CapabilityError{Feature: "native " + gadget.Name(), Curve: curveID}
+	}
+	return nativeHash(h, curveID, inputs...), nil
+}
+
+func nativeHash(h hash.Hash, curveID ecc.ID, inputs ...*big.Int) *big.Int {
+	modulus := curveID.ScalarField()
+	hFunc := h.New()
+	block := make([]byte, hFunc.BlockSize())
+	for _, in := range inputs {
+		v := new(big.Int).Mod(in, modulus)
+		hFunc.Write(v.FillBytes(block))
+	}
+	return new(big.Int).SetBytes(hFunc.Sum(nil))
+}
+
+// CircuitName identifies a circuit type. Generic circuits report their hash
+// gadget, e.g. HashCircuit[Poseidon2], so their specializations never share
+// a name.
+func CircuitName(circuit frontend.Circuit) string {
+	if named, ok := circuit.(interface{ CircuitName() string }); ok {
+		return named.CircuitName()
+	}
+	t := reflect.TypeOf(circuit)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,134 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+// testHashCircuitOf is the correctness matrix every HashCircuitOf
+// specialization must pass.
+func testHashCircuitOf[G HashGadget](t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var gadget G
+	var circuit HashCircuitOf[G]
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		for _, preImage := range []int64{0, 35, 1 << 40} {
+			hash, err := GadgetHash(gadget, curveID, big.NewInt(preImage))
+			if err != nil {
+				t.Fatalf("Failed to compute %s hash: %v", gadget.Name(), err)
+			}
+
+			assert.ProverSucceeded(&circuit, &HashCircuitOf[G]{
+				PreImage: preImage,
+				Hash:     hash,
+			}, test.WithCurves(curveID))
+
+			assert.ProverFailed(&circuit, &HashCircuitOf[G]{
+				PreImage: preImage + 1,
+				Hash:     hash,
+			}, test.WithCurves(curveID))
+		}
+	}
+}
+
+func TestHashCircuitOfMiMC(t *testing.T) { testHashCircuitOf[MiMCGadget](t) }
+
+func TestHashCircuitOfPoseidon(t *testing.T) { testHashCircuitOf[PoseidonGadget](t) }
+
+func TestHashCircuitOfSpecializationsDiffer(t *testing.T) {
+	mimcCircuit, poseidonCircuit := &HashCircuitOf[MiMCGadget]{}, &HashCircuitOf[PoseidonGadget]{}
+
+	if CircuitName(mimcCircuit) != "HashCircuit[MiMC]" || CircuitName(poseidonCircuit) != "HashCircuit[Poseidon2]" {
+		t.Fatalf("Unexpected circuit names: %s, %s", CircuitName(mimcCircuit), CircuitName(poseidonCircuit))
+	}
+
+	var digests []string
+	for _, circuit := range []frontend.Circuit{mimcCircuit, poseidonCircuit} {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+		if err != nil {
+			t.Fatalf("Failed to compile %s: %v", CircuitName(circuit), err)
+		}
+		digest, err := Fingerprint(ccs)
+		if err != nil {
+			t.Fatalf("Failed to fingerprint %s: %v", CircuitName(circuit), err)
+		}
+		t.Logf("%s constraints: %d", CircuitName(circuit), ccs.GetNbConstraints())
+		digests = append(digests, digest)
+	}
+	if digests[0] == digests[1] {
+		t.Fatal("Specializations compiled to the same constraint system")
+	}
+
+	x := big.NewInt(35)
+	mimcHash, _ := GadgetHash(MiMCGadget{}, ecc.BN254, x)
+	poseidonHash, _ := GadgetHash(PoseidonGadget{}, ecc.BN254, x)
+	if mimcHash.Cmp(poseidonHash) == 0 {
+		t.Fatal("MiMC and Poseidon2 produced the same digest")
+	}
+
+	// The registry keeps one entry per specialization.
+	built, _, err := NewAssignment("HashCircuit[Poseidon2]", WithSecret("PreImage", x), WithAutoHash())
+	if err != nil {
+		t.Fatalf("Failed to build Poseidon2 assignment: %v", err)
+	}
+	if _, ok := built.(*HashCircuitOf[PoseidonGadget]); !ok {
+		t.Fatalf("Registry built %T for HashCircuit[Poseidon2]", built)
+	}
+	if built.(*HashCircuitOf[PoseidonGadget]).Hash.(*big.Int).Cmp(poseidonHash) != 0 {
+		t.Fatal("Auto hash did not use the Poseidon2 gadget")
+	}
+
+	built, _, err = NewAssignment("HashCircuit[MiMC]", WithSecret("PreImage", x), WithAutoHash())
+	if err != nil {
+		t.Fatalf("Failed to build MiMC assignment: %v", err)
+	}
+	if built.(*HashCircuit).Hash.(*big.Int).Cmp(mimcHash) != 0 {
+		t.Fatal("Auto hash did not use the MiMC gadget")
+	}
+}
+
+func TestPoseidon2HashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit Poseidon2HashCircuit
+
+	hash, err := Poseidon2Hash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute Poseidon2 hash: %v", err)
+	}
+	mimcHash, err := MiMCHash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+
+	assert.ProverSucceeded(&circuit, &Poseidon2HashCircuit{PreImage: 35, Hash: hash}, test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, &Poseidon2HashCircuit{PreImage: 35, Hash: mimcHash}, test.WithCurves(ecc.BN254))
+}
+
+// BenchmarkHashGadgets compares the constraint counts and proving times of
+// the hash circuits. gnark has no gadget for the original Poseidon, so only
+// MiMC and Poseidon2 are measured.
+func BenchmarkHashGadgets(b *testing.B) {
+	for _, gadget := range []HashGadget{MiMCGadget{}, PoseidonGadget{}} {
+		b.Run(gadget.Name(), func(b *testing.B) {
+			name := "HashCircuit[" + gadget.Name() + "]"
+			assignment, _, err := NewAssignment(name, WithSecret("PreImage", big.NewInt(35)), WithAutoHash())
+			if err != nil {
+				b.Fatalf("Failed to build assignment: %v", err)
+			}
+			result, err := RunBench(ecc.BN254, assignableCircuits[name](), assignment, b.N)
+			if err != nil {
+				b.Fatalf("Failed to benchmark: %v", err)
+			}
+			b.ReportMetric(float64(result.Constraints), "constraints")
+			b.ReportMetric(float64(result.ProveNsPerOp), "prove-ns/op")
+		})
+	}
+}
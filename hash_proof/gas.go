@@ -0,0 +1,77 @@
+package hash_proof
+
+import "errors"
+
+// Gas costs of the BN254 precompiles after EIP-1108 and of calldata after
+// EIP-2028, as used by the exported Groth16 verifier.
+const (
+	txBaseGas         = 21000
+	calldataWordGas   = 32 * 16 // every byte counted as non-zero
+	selectorGas       = 4 * 16
+	ecAddGas          = 150
+	ecMulGas          = 6000
+	pairingBaseGas    = 45000
+	pairingPerPairGas = 34000
+
+	// verifierPairs is the number of pairings checked by verifyProof:
+	// e(A, B), e(C, -δ), e(α, -β) and e(L_pub, -γ).
+	verifierPairs = 4
+	// verifierOverheadGas covers the verifier's own execution besides the
+	// precompiles: memory, calldata copies and the input range checks.
+	verifierOverheadGas = 8000
+	// proofWords is the size of an uncompressed proof in 32-byte words.
+	proofWords = 8
+
+	// commitmentPairs is the number of pairings of the proof of knowledge
+	// of the Pedersen commitments, checked in a second pairing call.
+	commitmentPairs = 2
+	// commitmentPokWords is the size of that proof of knowledge in words.
+	commitmentPokWords = 2
+	// commitmentHashGas covers hashing one commitment into the public input
+	// it is committed to, with its memory copies.
+	commitmentHashGas = 2000
+)
+
+// GasEstimate is the expected cost of one on-chain verifyProof transaction.
+type GasEstimate struct {
+	CalldataBytes int `json:"calldataBytes"`
+	CalldataGas   int `json:"calldataGas"`
+	ExecutionGas  int `json:"executionGas"`
+	TotalGas      int `json:"totalGas"`
+}
+
+// EstimateVerifyGas estimates the gas of calling the exported verifier's
+// verifyProof with nbPublicInputs public inputs and nbCommitments BSB22
+// commitments. Calldata is the 8-word proof, then two words per commitment
+// and the 2-word commitment proof of knowledge if there are commitments,
+// then one word per input. Execution is the public input MSM, which also
+// covers one hashed input per commitment, and the pairing check, plus the
+// pairing check of the proof of knowledge. The estimate is an upper bound on
+// calldata cost since every byte is priced as non-zero.
+func EstimateVerifyGas(nbPublicInputs, nbCommitments int) (GasEstimate, error) {
+	if nbPublicInputs < 0 {
+		return GasEstimate{}, errors.New("number of public inputs cannot be negative")
+	}
+	if nbCommitments < 0 {
+		return GasEstimate{}, errors.New("number of commitments cannot be negative")
+	}
+
+	words := proofWords + nbPublicInputs
+	execution := verifierOverheadGas +
+		nbPublicInputs*(ecMulGas+ecAddGas) +
+		pairingBaseGas + verifierPairs*pairingPerPairGas
+	if nbCommitments > 0 {
+		words += 2*nbCommitments + commitmentPokWords
+		// Each commitment adds a hashed input to the MSM and its point to
+		// the MSM result.
+		execution += nbCommitments*(commitmentHashGas+ecMulGas+2*ecAddGas) +
+			pairingBaseGas + commitmentPairs*pairingPerPairGas
+	}
+	e := GasEstimate{
+		CalldataBytes: 4 + 32*words,
+		CalldataGas:   selectorGas + calldataWordGas*words,
+		ExecutionGas:  execution,
+	}
+	e.TotalGas = txBaseGas + e.CalldataGas + e.ExecutionGas
+	return e, nil
+}
@@ -0,0 +1,51 @@
+package hash_proof
+
+import "testing"
+
+func TestEstimateVerifyGas(t *testing.T) {
+	var prev GasEstimate
+	for n := 0; n <= 16; n++ {
+		e, err := EstimateVerifyGas(n, 0)
+		if err != nil {
+			t.Fatalf("Failed to estimate gas for %d inputs: %v", n, err)
+		}
+		if e.CalldataBytes != 4+32*(8+n) {
+			t.Fatalf("Unexpected calldata size for %d inputs: %d", n, e.CalldataBytes)
+		}
+		if e.TotalGas != txBaseGas+e.CalldataGas+e.ExecutionGas {
+			t.Fatalf("Total gas %d is not the sum of its parts", e.TotalGas)
+		}
+		// A Groth16 verification costs a few hundred thousand gas.
+		if e.TotalGas < 200000 || e.TotalGas > 500000 {
+			t.Fatalf("Implausible gas for %d inputs: %d", n, e.TotalGas)
+		}
+		if n > 0 && (e.TotalGas <= prev.TotalGas || e.CalldataBytes <= prev.CalldataBytes) {
+			t.Fatalf("Estimate is not monotonic: %+v after %+v", e, prev)
+		}
+		prev = e
+	}
+
+	// Commitment verifiers also take the commitments and their proof of
+	// knowledge, and check it with a second pairing call.
+	plain, err := EstimateVerifyGas(2, 0)
+	if err != nil {
+		t.Fatalf("Failed to estimate gas: %v", err)
+	}
+	committed, err := EstimateVerifyGas(2, 1)
+	if err != nil {
+		t.Fatalf("Failed to estimate gas with a commitment: %v", err)
+	}
+	if committed.CalldataBytes != plain.CalldataBytes+32*4 {
+		t.Fatalf("Unexpected calldata size with a commitment: %d", committed.CalldataBytes)
+	}
+	if committed.ExecutionGas < plain.ExecutionGas+pairingBaseGas+commitmentPairs*pairingPerPairGas {
+		t.Fatalf("Commitment proof of knowledge is not priced: %+v against %+v", committed, plain)
+	}
+
+	if _, err := EstimateVerifyGas(-1, 0); err == nil {
+		t.Fatal("Expected a negative input count to be rejected")
+	}
+	if _, err := EstimateVerifyGas(0, -1); err == nil {
+		t.Fatal("Expected a negative commitment count to be rejected")
+	}
+}
@@ -0,0 +1,49 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	_ "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+)
+
+var mimcByCurve = map[ecc.ID]hash.Hash{
+	ecc.BN254:     hash.MIMC_BN254,
+	ecc.BLS12_381: hash.MIMC_BLS12_381,
+	ecc.BLS12_377: hash.MIMC_BLS12_377,
+	ecc.BW6_761:   hash.MIMC_BW6_761,
+	ecc.BW6_633:   hash.MIMC_BW6_633,
+}
+
+// MiMCHash computes out of circuit the same digest the MiMC gadget produces
+// when the inputs are written to it in order, under the current hash suite.
+func MiMCHash(curveID ecc.ID, inputs ...*big.Int) (*big.Int, error) {
+	return MiMCHashWithSuite(CurrentHashSuite, curveID, inputs...)
+}
+
+// MiMCHashWithSuite is MiMCHash under an explicit hash suite. It fails if the
+// linked MiMC implementation does not match the suite's pinned parameters.
+func MiMCHashWithSuite(suite HashSuite, curveID ecc.ID, inputs ...*big.Int) (*big.Int, error) {
+	if err := CheckHashSuite(suite); err != nil {
+		return nil, err
+	}
+
+	h, ok := mimcByCurve[curveID]
+	if !ok {
+		return nil, &CapabilityError{Feature: "native MiMC", Curve: curveID}
+	}
+
+	return nativeHash(h, curveID, inputs...), nil
+}
+
+// Poseidon2Hash computes out of circuit the digest of Poseidon2HashCircuit
+// when the inputs are written to it in order.
+func Poseidon2Hash(curveID ecc.ID, inputs ...*big.Int) (*big.Int, error) {
+	return GadgetHash(PoseidonGadget{}, curveID, inputs...)
+}
@@ -0,0 +1,97 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	gohash "hash"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// HashSuite identifies the MiMC parameters a commitment was produced with.
+// gnark-crypto has changed MiMC round constants between releases before, so
+// commitments and verifiers are only comparable within one suite.
+type HashSuite uint8
+
+const (
+	HashSuiteV1 HashSuite = 1
+
+	CurrentHashSuite = HashSuiteV1
+)
+
+// hashSuiteDigests pins, per suite and curve, the SHA-256 of the native MiMC
+// digests of a fixed set of inputs. A linked gnark-crypto whose parameters
+// differ produces different digests and is refused.
+var hashSuiteDigests = map[HashSuite]map[ecc.ID]string{
+	HashSuiteV1: {
+		ecc.BN254:     "6121ad1742dabc35e54471f1d36280a2a0aec98028b8d080b7e8c9de8e7f0f47",
+		ecc.BLS12_381: "bf82048c6fab9785507cb5dce0a386837b7c54f57f0bf6f4869d411b5151db78",
+		ecc.BLS12_377: "e5f5bfcc2d51b5dc66e5251be42b5b5df5f9cc08c32fbcd6759b5b82f47d51b0",
+		ecc.BW6_761:   "f4cfa1c79954ce9eed09f3ee94aa5575c4a3bbf2bb6cdb5df65e74823400d08f",
+		ecc.BW6_633:   "ae9344c8bb974620f09ba95fc5b7e9845cb455c011ae18ab0746fc44ebc7620c",
+	},
+}
+
+// hashSuiteVectors are the inputs hashed to compute a suite digest.
+var hashSuiteVectors = [][]int64{{0}, {1}, {35}, {1, 2}, {-1}}
+
+var (
+	hashSuiteMu       sync.Mutex
+	hashSuiteVerified = map[HashSuite]error{}
+)
+
+// CheckHashSuite verifies that the linked MiMC implementation matches the
+// pinned parameters of suite on every supported curve. The result is cached
+// for the lifetime of the process.
+func CheckHashSuite(suite HashSuite) error {
+	hashSuiteMu.Lock()
+	defer hashSuiteMu.Unlock()
+
+	err, ok := hashSuiteVerified[suite]
+	if !ok {
+		err = checkHashSuite(suite, func(curveID ecc.ID) gohash.Hash {
+			return mimcByCurve[curveID].New()
+		})
+		hashSuiteVerified[suite] = err
+	}
+	return err
+}
+
+func checkHashSuite(suite HashSuite, newHasher func(ecc.ID) gohash.Hash) error {
+	pinned, ok := hashSuiteDigests[suite]
+	if !ok {
+		return fmt.Errorf("unknown hash suite %d", suite)
+	}
+
+	curves := make([]ecc.ID, 0, len(pinned))
+	for curveID := range pinned {
+		curves = append(curves, curveID)
+	}
+	sort.Slice(curves, func(i, j int) bool { return curves[i] < curves[j] })
+
+	for _, curveID := range curves {
+		if got := hashSuiteDigest(curveID, newHasher(curveID)); got != pinned[curveID] {
+			return fmt.Errorf("MiMC parameters for %s do not match hash suite %d: digest %s, pinned %s", curveID, suite, got, pinned[curveID])
+		}
+	}
+	return nil
+}
+
+func hashSuiteDigest(curveID ecc.ID, hFunc gohash.Hash) string {
+	modulus := curveID.ScalarField()
+	block := make([]byte, hFunc.BlockSize())
+	digest := sha256.New()
+	for _, vector := range hashSuiteVectors {
+		hFunc.Reset()
+		for _, in := range vector {
+			v := new(big.Int).Mod(big.NewInt(in), modulus)
+			hFunc.Write(v.FillBytes(block))
+		}
+		digest.Write(hFunc.Sum(nil))
+	}
+	return hex.EncodeToString(digest.Sum(nil))
+}
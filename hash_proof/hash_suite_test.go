@@ -0,0 +1,35 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	gohash "hash"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestCheckHashSuite(t *testing.T) {
+	if err := CheckHashSuite(CurrentHashSuite); err != nil {
+		t.Fatalf("Linked MiMC does not match the current suite: %v", err)
+	}
+
+	if err := CheckHashSuite(HashSuite(0)); err == nil {
+		t.Fatal("Expected an unknown hash suite to be rejected")
+	}
+	if _, err := MiMCHashWithSuite(HashSuite(0), ecc.BN254, big.NewInt(35)); err == nil {
+		t.Fatal("Expected hashing under an unknown suite to fail")
+	}
+}
+
+func TestCheckHashSuiteDetectsDrift(t *testing.T) {
+	drifted := func(ecc.ID) gohash.Hash { return sha256.New() }
+	if err := checkHashSuite(CurrentHashSuite, drifted); err == nil {
+		t.Fatal("Expected drifted MiMC parameters to be rejected")
+	}
+
+	linked := func(curveID ecc.ID) gohash.Hash { return mimcByCurve[curveID].New() }
+	if err := checkHashSuite(CurrentHashSuite, linked); err != nil {
+		t.Fatalf("Failed to check linked MiMC: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestMiMCHash(t *testing.T) {
+	hash, err := MiMCHash(ecc.BN254, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+
+	expected := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	if hash.String() != expected {
+		t.Fatalf("Unexpected hash: got %s, want %s", hash, expected)
+	}
+
+	hash, err = MiMCHash(ecc.BW6_633, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute BW6-633 MiMC hash: %v", err)
+	}
+	expected = "9968387491322785805085586073883329841826030190403950669068884412494573739951963330296838078171"
+	if hash.String() != expected {
+		t.Fatalf("Unexpected BW6-633 hash: got %s, want %s", hash, expected)
+	}
+
+	if _, err := MiMCHash(ecc.UNKNOWN, big.NewInt(35)); err == nil {
+		t.Fatal("Expected an error for an unsupported curve")
+	}
+}
+
+func TestMiMCHashEdgeCases(t *testing.T) {
+	maxElement := new(big.Int).Sub(ecc.BN254.ScalarField(), big.NewInt(1))
+
+	testCases := []struct {
+		name     string
+		preImage *big.Int
+		expected string
+	}{
+		{name: "zero", preImage: big.NewInt(0), expected: "20104241803663641422577121134203490505137011783614913652735802145961801733870"},
+		{name: "modulus minus one", preImage: maxElement, expected: "5735250364431135799044874625754725871697248646633056697741385825866941459957"},
+		// TestHashCircuit's failing case claims 42 hashes to 42.
+		{name: "42", preImage: big.NewInt(42), expected: "9859286970797740035380527431348382675909558438535884267813507963157263542611"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := MiMCHash(ecc.BN254, tc.preImage)
+			if err != nil {
+				t.Fatalf("Failed to compute MiMC hash: %v", err)
+			}
+			if hash.String() != tc.expected {
+				t.Fatalf("Unexpected hash: got %s, want %s", hash, tc.expected)
+			}
+		})
+	}
+
+	// Inputs are reduced modulo the field, so the modulus hashes like zero.
+	hash, err := MiMCHash(ecc.BN254, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	if hash.String() != testCases[0].expected {
+		t.Fatalf("The modulus does not hash like zero: %s", hash)
+	}
+}
+
+// TestHashHelpersEdgeCases pins the native helpers on the same edge cases as
+// TestMiMCHashEdgeCases: zero, the largest input each accepts and 42, which a
+// failing test claims hashes to itself. Every digest element is listed.
+func TestHashHelpersEdgeCases(t *testing.T) {
+	maxElement := new(big.Int).Sub(ecc.BN254.ScalarField(), big.NewInt(1))
+	maxBits := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), BitCommitmentBits), big.NewInt(1))
+	one := func(h *big.Int, err error) ([]*big.Int, error) { return []*big.Int{h}, err }
+	two := func(a, b *big.Int, err error) ([]*big.Int, error) { return []*big.Int{a, b}, err }
+
+	testCases := []struct {
+		name     string
+		hash     func(x *big.Int) ([]*big.Int, error)
+		max      *big.Int
+		expected [3][]string // zero, max, 42
+	}{
+		{
+			name: "Poseidon2Hash",
+			hash: func(x *big.Int) ([]*big.Int, error) { return one(Poseidon2Hash(ecc.BN254, x)) },
+			max:  maxElement,
+			expected: [3][]string{
+				{"18622970401557034651033185129330286139447343337105683528700775943440799145467"},
+				{"9203995432177220598861046485553026446505706914670335129122222786750795879989"},
+				{"13565356373417538528006943412468816847646309577018621962105802276859759061675"},
+			},
+		},
+		{
+			name: "MetadataHash",
+			hash: func(x *big.Int) ([]*big.Int, error) { return one(MetadataHash(ecc.BN254, x, x)) },
+			max:  maxElement,
+			expected: [3][]string{
+				{"2403556985057630347612871867686535364113859288490902048310028832517023898098"},
+				{"792511623129048155968533333106785216837791132453202484304756068253878737197"},
+				{"9897469370395277282605095350315674608574014698560853183168918691269742640532"},
+			},
+		},
+		{
+			name: "CanonicalHash",
+			hash: func(x *big.Int) ([]*big.Int, error) {
+				return one(CanonicalHash(ecc.BN254, map[string]*big.Int{"secret": x}, []string{"secret"}))
+			},
+			max: maxElement,
+			expected: [3][]string{
+				{"4842459721281700754403554995228785874162100022569944803763200847609256940514"},
+				{"1860517888513736409829617688608917297797711187215525232845396011339443484310"},
+				{"3188791453699684162461104348537120083121043511904314634594781056995608209555"},
+			},
+		},
+		{
+			// Zero commits to the identity point of Baby Jubjub.
+			name: "PedersenHash",
+			hash: func(x *big.Int) ([]*big.Int, error) { return two(PedersenHash(x)) },
+			max:  maxElement,
+			expected: [3][]string{
+				{"0", "1"},
+				{"7413993884417015042318981627212207953305086777226680808479917976829370498415", "11400336289082787841527791844953764924923905419339993911657290080745955312951"},
+				{"19435185250615292306125049629057506756664237985339589582043542259708405891054", "16414789158706146034337677946720139175629582444207655085744951462751993091228"},
+			},
+		},
+		{
+			name: "BridgeCommitments",
+			hash: func(x *big.Int) ([]*big.Int, error) {
+				return two(BridgeCommitments(ecc.BN254, x, big.NewInt(1), big.NewInt(2)))
+			},
+			max: maxElement,
+			expected: [3][]string{
+				{"2523720666621319487696459377590490465309500609129948937111994540703163481652", "1466774472180967278372041104535825845382365157433251809158823228607109805216"},
+				{"15683547333281355814624115500989938562164179009726103199905009534957224434807", "18605462274530756195835981652756053774652654104363561244908332007027611060873"},
+				{"8165839926339826412223800085588241132661348597009880506291407773832088909844", "19406122281721845491852138343186496689168205116985841247198034874066562647102"},
+			},
+		},
+		{
+			name: "BitCommitment",
+			hash: func(x *big.Int) ([]*big.Int, error) {
+				c, err := BitCommitment(x, x)
+				return c[:], err
+			},
+			max: maxBits,
+			expected: [3][]string{
+				{"136123742680664453477343918734746553888", "11418277011772585854330833085247990053"},
+				{"233394138510626709938524068408033788831", "24835749182806129982237330403221061713"},
+				{"266694624336670046110789556800047801165", "12191792275524262111444602027453779422"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, x := range []*big.Int{big.NewInt(0), tc.max, big.NewInt(42)} {
+				got, err := tc.hash(x)
+				if err != nil {
+					t.Fatalf("Failed to hash %s: %v", x, err)
+				}
+				for j, h := range got {
+					if h.String() != tc.expected[i][j] {
+						t.Fatalf("Unexpected digest element %d of %s: got %s, want %s", j, x, h, tc.expected[i][j])
+					}
+					if i > 0 && h.Cmp(x) == 0 {
+						t.Fatalf("%s hashes to itself", x)
+					}
+				}
+			}
+
+			// Inputs beyond the largest are rejected or reduced like zero.
+			beyond := new(big.Int).Add(tc.max, big.NewInt(1))
+			got, err := tc.hash(beyond)
+			if err == nil && got[0].String() != tc.expected[0][0] {
+				t.Fatalf("%s is neither rejected nor reduced like zero: %s", beyond, got[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package hash_proof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+var intervalLeafSchema = []string{"low", "high"}
+
+// IntervalTreeCircuit proves that the preimage of Hash lies in [Low, High],
+// where the canonical hash of Low and High is a leaf of the interval tree with
+// the public Root.
+// The tree has depth MerkleDepth; which interval was used stays secret.
+type IntervalTreeCircuit struct {
+	PreImage    frontend.Variable              `gnark:",secret"`
+	Low         frontend.Variable              `gnark:",secret"`
+	High        frontend.Variable              `gnark:",secret"`
+	Siblings    [MerkleDepth]frontend.Variable `gnark:",secret"`
+	PathIndices [MerkleDepth]frontend.Variable `gnark:",secret"`
+	Hash        frontend.Variable              `gnark:",public"`
+	Root        frontend.Variable              `gnark:",public"`
+}
+
+func (circuit *IntervalTreeCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	leaf, err := canonicalHash(api, circuit.Low, circuit.High)
+	if err != nil {
+		return err
+	}
+	node, err := merkleRoot(api, leaf, circuit.Siblings[:], circuit.PathIndices[:])
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.Root, node)
+
+	api.AssertIsLessOrEqual(circuit.Low, circuit.PreImage)
+	api.AssertIsLessOrEqual(circuit.PreImage, circuit.High)
+
+	return nil
+}
+
+// IntervalTree is the native tree an IntervalTreeCircuit proves against. Its
+// leaves are the canonical hashes of sorted, disjoint intervals; unused
+// leaves are zero, which no interval hashes to.
+type IntervalTree struct {
+	curveID   ecc.ID
+	intervals [][2]*big.Int
+	levels    [][]*big.Int
+}
+
+// NewIntervalTree builds the tree of intervals, each given as {low, high}
+// with low <= high, in increasing and non-overlapping order. At most
+// 2^MerkleDepth intervals fit.
+func NewIntervalTree(curveID ecc.ID, intervals [][2]*big.Int) (*IntervalTree, error) {
+	if len(intervals) > 1<<MerkleDepth {
+		return nil, fmt.Errorf("%d intervals do not fit in %d leaves", len(intervals), 1<<MerkleDepth)
+	}
+
+	leaves := make([]*big.Int, 1<<MerkleDepth)
+	for i := range leaves {
+		leaves[i] = new(big.Int)
+	}
+	for i, iv := range intervals {
+		if iv[0].Cmp(iv[1]) > 0 {
+			return nil, fmt.Errorf("interval %d has low %s above high %s", i, iv[0], iv[1])
+		}
+		if i > 0 && iv[0].Cmp(intervals[i-1][1]) <= 0 {
+			return nil, fmt.Errorf("interval %d overlaps or precedes interval %d", i, i-1)
+		}
+		leaf, err := CanonicalHash(curveID, map[string]*big.Int{
+			"low":  iv[0],
+			"high": iv[1],
+		}, intervalLeafSchema)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = leaf
+	}
+
+	levels, err := merkleLevelsFromLeaves(curveID, leaves)
+	if err != nil {
+		return nil, err
+	}
+	return &IntervalTree{curveID: curveID, intervals: intervals, levels: levels}, nil
+}
+
+// Root returns the root of the tree.
+func (t *IntervalTree) Root() *big.Int {
+	return t.levels[MerkleDepth][0]
+}
+
+// Assignment finds the interval holding secret and fills an
+// IntervalTreeCircuit assignment with its path.
+func (t *IntervalTree) Assignment(secret *big.Int) (*IntervalTreeCircuit, error) {
+	for index, iv := range t.intervals {
+		if secret.Cmp(iv[0]) < 0 || secret.Cmp(iv[1]) > 0 {
+			continue
+		}
+
+		hash, err := MiMCHash(t.curveID, secret)
+		if err != nil {
+			return nil, err
+		}
+		assignment := &IntervalTreeCircuit{
+			PreImage: secret,
+			Low:      iv[0],
+			High:     iv[1],
+			Hash:     hash,
+			Root:     t.Root(),
+		}
+		for i := 0; i < MerkleDepth; i, index = i+1, index>>1 {
+			assignment.Siblings[i] = t.levels[i][index^1]
+			assignment.PathIndices[i] = index & 1
+		}
+		return assignment, nil
+	}
+	return nil, errors.New("secret is in no interval")
+}
@@ -0,0 +1,60 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestIntervalTreeCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	tree, err := NewIntervalTree(ecc.BN254, [][2]*big.Int{
+		{big.NewInt(0), big.NewInt(9)},
+		{big.NewInt(20), big.NewInt(40)},
+		{big.NewInt(100), big.NewInt(100)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build interval tree: %v", err)
+	}
+
+	var circuit IntervalTreeCircuit
+	for _, secret := range []int64{0, 35, 40, 100} {
+		assignment, err := tree.Assignment(big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to build assignment for %d: %v", secret, err)
+		}
+		assert.ProverSucceeded(&circuit, assignment, test.WithCurves(ecc.BN254))
+	}
+
+	if _, err := tree.Assignment(big.NewInt(50)); err == nil {
+		t.Fatal("Expected a secret in no interval to be rejected by the builder")
+	}
+
+	// Reuse the path of [20, 40] for a value outside it.
+	outside, err := tree.Assignment(big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to build assignment: %v", err)
+	}
+	hash, err := MiMCHash(ecc.BN254, big.NewInt(41))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	outside.PreImage, outside.Hash = 41, hash
+	assert.ProverFailed(&circuit, outside, test.WithCurves(ecc.BN254))
+
+	// Widening the interval breaks the inclusion proof.
+	outside.High = 41
+	assert.ProverFailed(&circuit, outside, test.WithCurves(ecc.BN254))
+
+	for name, intervals := range map[string][][2]*big.Int{
+		"inverted":    {{big.NewInt(5), big.NewInt(1)}},
+		"overlapping": {{big.NewInt(0), big.NewInt(10)}, {big.NewInt(10), big.NewInt(20)}},
+	} {
+		if _, err := NewIntervalTree(ecc.BN254, intervals); err == nil {
+			t.Fatalf("Expected %s intervals to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// LinearSystemCircuit proves knowledge of a secret pair (X, Y) solving
+//
+//	A1*X + B1*Y == C1
+//	A2*X + B2*Y == C2
+//
+// over the scalar field for public coefficients, where X and Y are the
+// preimages of the public commitments HashX and HashY.
+type LinearSystemCircuit struct {
+	X     frontend.Variable `gnark:",secret"`
+	Y     frontend.Variable `gnark:",secret"`
+	A1    frontend.Variable `gnark:",public"`
+	B1    frontend.Variable `gnark:",public"`
+	C1    frontend.Variable `gnark:",public"`
+	A2    frontend.Variable `gnark:",public"`
+	B2    frontend.Variable `gnark:",public"`
+	C2    frontend.Variable `gnark:",public"`
+	HashX frontend.Variable `gnark:",public"`
+	HashY frontend.Variable `gnark:",public"`
+}
+
+func (circuit *LinearSystemCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.X)
+	api.AssertIsEqual(circuit.HashX, hFunc.Sum())
+
+	hFunc.Reset()
+	hFunc.Write(circuit.Y)
+	api.AssertIsEqual(circuit.HashY, hFunc.Sum())
+
+	api.AssertIsEqual(api.Add(api.Mul(circuit.A1, circuit.X), api.Mul(circuit.B1, circuit.Y)), circuit.C1)
+	api.AssertIsEqual(api.Add(api.Mul(circuit.A2, circuit.X), api.Mul(circuit.B2, circuit.Y)), circuit.C2)
+
+	return nil
+}
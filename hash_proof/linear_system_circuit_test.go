@@ -0,0 +1,43 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestLinearSystemCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit LinearSystemCircuit
+
+	// 3x + 2y = 29 and x - y = -2 have the solution x = 5, y = 7.
+	assignment := func(x, y int64) *LinearSystemCircuit {
+		hashX, err := MiMCHash(ecc.BN254, big.NewInt(x))
+		if err != nil {
+			t.Fatalf("Failed to hash x: %v", err)
+		}
+		hashY, err := MiMCHash(ecc.BN254, big.NewInt(y))
+		if err != nil {
+			t.Fatalf("Failed to hash y: %v", err)
+		}
+		return &LinearSystemCircuit{
+			X: x, Y: y,
+			A1: 3, B1: 2, C1: 29,
+			A2: 1, B2: -1, C2: -2,
+			HashX: hashX, HashY: hashY,
+		}
+	}
+
+	assert.ProverSucceeded(&circuit, assignment(5, 7), test.WithCurves(ecc.BN254))
+
+	// (7, 4) satisfies the first equation but not the second.
+	assert.ProverFailed(&circuit, assignment(7, 4), test.WithCurves(ecc.BN254))
+
+	// The solution must match the committed values.
+	wrongCommitment := assignment(5, 7)
+	wrongCommitment.HashY = wrongCommitment.HashX
+	assert.ProverFailed(&circuit, wrongCommitment, test.WithCurves(ecc.BN254))
+}
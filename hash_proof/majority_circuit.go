@@ -0,0 +1,55 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// MajorityVoters is the number of votes MajorityVoteCircuit tallies. It is
+// even so that ties are possible; a tie is not a majority.
+const MajorityVoters = 4
+
+var majorityVoteSchema = []string{"vote", "salt"}
+
+// MajorityVoteCircuit proves that Majority is 1 exactly when more than half
+// of the secret votes are yes. Each vote is 0 or 1 and committed as the
+// canonical hash of vote and salt; the salt keeps a one-bit vote from being
+// recovered by hashing both candidates.
+type MajorityVoteCircuit struct {
+	Votes    [MajorityVoters]frontend.Variable `gnark:",secret"`
+	Salts    [MajorityVoters]frontend.Variable `gnark:",secret"`
+	Hashes   [MajorityVoters]frontend.Variable `gnark:",public"`
+	Majority frontend.Variable                 `gnark:",public"`
+}
+
+func (circuit *MajorityVoteCircuit) Define(api frontend.API) error {
+	var yes frontend.Variable = 0
+	for i := range circuit.Votes {
+		api.AssertIsBoolean(circuit.Votes[i])
+
+		hash, err := canonicalHash(api, circuit.Votes[i], circuit.Salts[i])
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(circuit.Hashes[i], hash)
+
+		yes = api.Add(yes, circuit.Votes[i])
+	}
+
+	// yes > N/2, with N/2 rounded down.
+	majority := api.IsZero(api.Sub(api.Cmp(yes, MajorityVoters/2), 1))
+	api.AssertIsEqual(circuit.Majority, majority)
+
+	return nil
+}
+
+// MajorityVoteHash computes the public commitment of MajorityVoteCircuit to
+// vote with salt.
+func MajorityVoteHash(curveID ecc.ID, vote, salt *big.Int) (*big.Int, error) {
+	return CanonicalHash(curveID, map[string]*big.Int{
+		"vote": vote,
+		"salt": salt,
+	}, majorityVoteSchema)
+}
@@ -0,0 +1,47 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestMajorityVoteCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit MajorityVoteCircuit
+
+	assignment := func(votes [MajorityVoters]int64, majority int) *MajorityVoteCircuit {
+		a := &MajorityVoteCircuit{Majority: majority}
+		for i, v := range votes {
+			salt := big.NewInt(1000 + int64(i))
+			hash, err := MajorityVoteHash(ecc.BN254, big.NewInt(v), salt)
+			if err != nil {
+				t.Fatalf("Failed to hash: %v", err)
+			}
+			a.Votes[i], a.Salts[i], a.Hashes[i] = v, salt, hash
+		}
+		return a
+	}
+
+	testCases := []struct {
+		name  string
+		votes [MajorityVoters]int64
+		want  int
+	}{
+		{name: "majority", votes: [MajorityVoters]int64{1, 1, 0, 1}, want: 1},
+		{name: "minority", votes: [MajorityVoters]int64{0, 1, 0, 0}, want: 0},
+		{name: "tie", votes: [MajorityVoters]int64{1, 0, 1, 0}, want: 0},
+	}
+	for _, tc := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			assert.ProverSucceeded(&circuit, assignment(tc.votes, tc.want), test.WithCurves(ecc.BN254))
+			assert.ProverFailed(&circuit, assignment(tc.votes, 1-tc.want), test.WithCurves(ecc.BN254))
+		}, tc.name)
+	}
+
+	// A vote of 2 would let two voters outweigh a tie.
+	assert.ProverFailed(&circuit, assignment([MajorityVoters]int64{2, 0, 1, 0}, 1), test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,134 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark/frontend"
+	"gopkg.in/yaml.v3"
+)
+
+// CircuitManifest describes a circuit and its compile-time parameters, so a
+// deployment can version its circuit configuration alongside its keys.
+type CircuitManifest struct {
+	Circuit string         `json:"circuit" yaml:"circuit"`
+	Params  map[string]int `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// circuitParam is a constructor parameter and the largest value it accepts.
+type circuitParam struct {
+	name string
+	max  int
+}
+
+type parameterizedCircuit struct {
+	params     []circuitParam
+	newCircuit func(p map[string]int) frontend.Circuit
+}
+
+// parameterizedCircuits are the circuits whose shape is fixed by a
+// constructor, with the parameters each constructor takes. Every parameter
+// must be a positive integer no larger than its max, so a manifest cannot
+// ask for a circuit too large to compile.
+var parameterizedCircuits = map[string]parameterizedCircuit{
+	"ForestMembershipCircuit": {
+		// Depths as in NewMerkleForest; each root is one public input.
+		params:     []circuitParam{{"roots", 256}, {"depth", 30}},
+		newCircuit: func(p map[string]int) frontend.Circuit { return NewForestMembershipCircuit(p["roots"], p["depth"]) },
+	},
+	"ShardHashCircuit": {
+		params:     []circuitParam{{"shards", 1 << 32}},
+		newCircuit: func(p map[string]int) frontend.Circuit { return NewShardHashCircuit(uint64(p["shards"])) },
+	},
+	"XORShareCircuit": {
+		// Each share is one public input.
+		params:     []circuitParam{{"shares", 256}},
+		newCircuit: func(p map[string]int) frontend.Circuit { return NewXORShareCircuit(p["shares"]) },
+	},
+	"BitReverseHashCircuit": {
+		// The secret and its reversal must fit the smallest supported
+		// scalar field, of 253 bits.
+		params:     []circuitParam{{"width", 252}},
+		newCircuit: func(p map[string]int) frontend.Circuit { return NewBitReverseHashCircuit(p["width"]) },
+	},
+}
+
+// CircuitFromManifest reads a JSON or YAML manifest, chosen by the file
+// extension, and returns the circuit definition it describes. Circuits
+// NewAssignment knows take no parameters; the others must be given exactly
+// the parameters of their constructor.
+func CircuitFromManifest(path string) (frontend.Circuit, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m CircuitManifest
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&m)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&m)
+	default:
+		return nil, fmt.Errorf("%s: unsupported manifest extension %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	circuit, err := m.NewCircuit()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return circuit, nil
+}
+
+// NewCircuit returns the circuit definition m describes.
+func (m CircuitManifest) NewCircuit() (frontend.Circuit, error) {
+	if m.Circuit == "" {
+		return nil, fmt.Errorf("manifest names no circuit")
+	}
+
+	if newCircuit, ok := lookupCircuit(m.Circuit); ok {
+		if len(m.Params) > 0 {
+			return nil, fmt.Errorf("%s takes no parameters", m.Circuit)
+		}
+		return newCircuit(), nil
+	}
+
+	pc, ok := parameterizedCircuits[m.Circuit]
+	if !ok {
+		return nil, fmt.Errorf("unknown circuit %q", m.Circuit)
+	}
+	for name, v := range m.Params {
+		i := slices.IndexFunc(pc.params, func(p circuitParam) bool { return p.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("%s has no parameter %q", m.Circuit, name)
+		}
+		if v < 1 {
+			return nil, fmt.Errorf("%s parameter %s must be positive, got %d", m.Circuit, name, v)
+		}
+		if v > pc.params[i].max {
+			return nil, fmt.Errorf("%s parameter %s must be at most %d, got %d", m.Circuit, name, pc.params[i].max, v)
+		}
+	}
+	var missing []string
+	for _, p := range pc.params {
+		if _, ok := m.Params[p.name]; !ok {
+			missing = append(missing, p.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s: missing parameters %s", m.Circuit, strings.Join(missing, ", "))
+	}
+	return pc.newCircuit(m.Params), nil
+}
@@ -0,0 +1,100 @@
+package hash_proof
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func writeManifest(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestCircuitFromManifest(t *testing.T) {
+	for _, path := range []string{
+		writeManifest(t, "circuit.json", `{"circuit": "ForestMembershipCircuit", "params": {"roots": 2, "depth": 4}}`),
+		writeManifest(t, "circuit.yaml", "circuit: ForestMembershipCircuit\nparams:\n  roots: 2\n  depth: 4\n"),
+	} {
+		circuit, err := CircuitFromManifest(path)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", filepath.Base(path), err)
+		}
+		forest, ok := circuit.(*ForestMembershipCircuit)
+		if !ok {
+			t.Fatalf("%s: unexpected circuit %T", filepath.Base(path), circuit)
+		}
+		if len(forest.Siblings) != 4 || len(forest.Roots) != 2 {
+			t.Fatalf("%s: got depth %d with %d roots", filepath.Base(path), len(forest.Siblings), len(forest.Roots))
+		}
+		if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit); err != nil {
+			t.Fatalf("Failed to compile circuit: %v", err)
+		}
+	}
+
+	circuit, err := CircuitFromManifest(writeManifest(t, "circuit.json", `{"circuit": "HashCircuit"}`))
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if _, ok := circuit.(*HashCircuit); !ok {
+		t.Fatalf("Unexpected circuit %T", circuit)
+	}
+}
+
+func TestCircuitFromManifestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"unknown circuit", "circuit.json", `{"circuit": "NoSuchCircuit"}`, `unknown circuit "NoSuchCircuit"`},
+		{"no circuit", "circuit.json", `{"params": {"depth": 4}}`, "names no circuit"},
+		{"missing parameter", "circuit.json", `{"circuit": "ForestMembershipCircuit", "params": {"depth": 4}}`, "missing parameters roots"},
+		{"unknown parameter", "circuit.yaml", "circuit: ForestMembershipCircuit\nparams: {roots: 1, depth: 4, arity: 3}\n", `no parameter "arity"`},
+		{"non-positive parameter", "circuit.json", `{"circuit": "ForestMembershipCircuit", "params": {"roots": 1, "depth": 0}}`, "depth must be positive"},
+		{"depth too large", "circuit.json", `{"circuit": "ForestMembershipCircuit", "params": {"roots": 1, "depth": 31}}`, "depth must be at most 30, got 31"},
+		{"too many roots", "circuit.yaml", "circuit: ForestMembershipCircuit\nparams: {roots: 257, depth: 4}\n", "roots must be at most 256"},
+		{"too many shards", "circuit.json", `{"circuit": "ShardHashCircuit", "params": {"shards": 4294967297}}`, "shards must be at most 4294967296"},
+		{"too many shares", "circuit.json", `{"circuit": "XORShareCircuit", "params": {"shares": 257}}`, "shares must be at most 256"},
+		{"width too large", "circuit.json", `{"circuit": "BitReverseHashCircuit", "params": {"width": 253}}`, "width must be at most 252"},
+		{"parameters on fixed circuit", "circuit.json", `{"circuit": "HashCircuit", "params": {"depth": 4}}`, "takes no parameters"},
+		{"unknown field", "circuit.json", `{"circuit": "HashCircuit", "curve": "bn254"}`, "unknown field"},
+		{"malformed", "circuit.yaml", "circuit: [", "circuit.yaml"},
+		{"unsupported extension", "circuit.toml", `circuit = "HashCircuit"`, "unsupported manifest extension"},
+	} {
+		_, err := CircuitFromManifest(writeManifest(t, tc.file, tc.content))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestManifestParameterBounds(t *testing.T) {
+	for name, pc := range parameterizedCircuits {
+		params := map[string]int{}
+		for _, p := range pc.params {
+			params[p.name] = p.max
+		}
+		if _, err := (CircuitManifest{Circuit: name, Params: params}).NewCircuit(); err != nil {
+			t.Errorf("%s rejects its largest parameters %v: %v", name, params, err)
+		}
+	}
+
+	// The largest width compiles on every curve.
+	width := parameterizedCircuits["BitReverseHashCircuit"].params[0].max
+	for _, curveID := range groth16Curves {
+		if _, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, NewBitReverseHashCircuit(width)); err != nil {
+			t.Errorf("Width %d does not compile on %s: %v", width, curveID, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// MedianHashCircuit proves that, among three secrets committed by public MiMC
+// hashes, the one at MedianIndex is their median.
+type MedianHashCircuit struct {
+	Values      [3]frontend.Variable `gnark:",secret"`
+	Hashes      [3]frontend.Variable `gnark:",public"`
+	MedianIndex frontend.Variable    `gnark:",public"`
+}
+
+func (circuit *MedianHashCircuit) Define(api frontend.API) error {
+	for i := range circuit.Values {
+		hFunc, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		hFunc.Write(circuit.Values[i])
+		api.AssertIsEqual(circuit.Hashes[i], hFunc.Sum())
+	}
+
+	bits := api.ToBinary(circuit.MedianIndex, 2)
+	api.AssertIsEqual(api.And(bits[0], bits[1]), 0)
+
+	v := circuit.Values
+	median := api.Lookup2(bits[0], bits[1], v[0], v[1], v[2], v[2])
+	other1 := api.Lookup2(bits[0], bits[1], v[1], v[0], v[0], v[0])
+	other2 := api.Lookup2(bits[0], bits[1], v[2], v[2], v[1], v[1])
+
+	swap := api.IsZero(api.Sub(api.Cmp(other1, other2), 1))
+	low := api.Select(swap, other2, other1)
+	high := api.Select(swap, other1, other2)
+
+	api.AssertIsLessOrEqual(low, median)
+	api.AssertIsLessOrEqual(median, high)
+
+	return nil
+}
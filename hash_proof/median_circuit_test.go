@@ -0,0 +1,35 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestMedianHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit MedianHashCircuit
+
+	values := [3]int64{10, 30, 20}
+	var assignment MedianHashCircuit
+	for i, v := range values {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(v))
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		assignment.Values[i] = v
+		assignment.Hashes[i] = hash
+	}
+
+	assignment.MedianIndex = 2
+	assert.ProverSucceeded(&circuit, &assignment, test.WithCurves(ecc.BN254))
+
+	for _, index := range []int{0, 1, 3} {
+		invalid := assignment
+		invalid.MedianIndex = index
+		assert.ProverFailed(&circuit, &invalid, test.WithCurves(ecc.BN254))
+	}
+}
@@ -0,0 +1,39 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+var metadataHashSchema = []string{"preImage", "metadata"}
+
+// MetadataHashCircuit proves knowledge of a preimage whose canonical hash
+// together with the public Metadata is Hash. Binding the metadata (a
+// recipient address, say) into the hash means a proof cannot be replayed
+// with different data: the verifier supplies Metadata itself.
+type MetadataHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Metadata frontend.Variable `gnark:",public"`
+	Hash     frontend.Variable `gnark:",public"`
+}
+
+func (circuit *MetadataHashCircuit) Define(api frontend.API) error {
+	hash, err := canonicalHash(api, circuit.PreImage, circuit.Metadata)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.Hash, hash)
+
+	return nil
+}
+
+// MetadataHash computes the public Hash of MetadataHashCircuit for preImage
+// bound to metadata.
+func MetadataHash(curveID ecc.ID, preImage, metadata *big.Int) (*big.Int, error) {
+	return CanonicalHash(curveID, map[string]*big.Int{
+		"preImage": preImage,
+		"metadata": metadata,
+	}, metadataHashSchema)
+}
@@ -0,0 +1,57 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestMetadataHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit MetadataHashCircuit
+
+	preImage := big.NewInt(35)
+	recipient := big.NewInt(0xA11CE)
+	other := big.NewInt(0xB0B)
+
+	hash, err := MetadataHash(ecc.BN254, preImage, recipient)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	otherHash, err := MetadataHash(ecc.BN254, preImage, other)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	if hash.Cmp(otherHash) == 0 {
+		t.Fatal("Different metadata produced the same hash")
+	}
+	raw, err := MiMCHash(ecc.BN254, preImage, recipient)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	if hash.Cmp(raw) == 0 {
+		t.Fatal("Metadata hash is not the canonical absorption")
+	}
+
+	assert.ProverSucceeded(&circuit, &MetadataHashCircuit{
+		PreImage: preImage,
+		Metadata: recipient,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+
+	// Replaying the hash with different metadata must fail.
+	assert.ProverFailed(&circuit, &MetadataHashCircuit{
+		PreImage: preImage,
+		Metadata: other,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverSucceeded(&circuit, &MetadataHashCircuit{
+		PreImage: preImage,
+		Metadata: other,
+		Hash:     otherHash,
+	}, test.WithCurves(ecc.BN254))
+}
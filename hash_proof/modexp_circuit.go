@@ -0,0 +1,69 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+const (
+	// ModExpModulusBits bounds the public modulus of ModExpHashCircuit so
+	// that the product of two residues stays below the field modulus.
+	ModExpModulusBits = 120
+	// ModExpExponentBits is the width of the secret exponent.
+	ModExpExponentBits = 64
+)
+
+// ModExpHashCircuit proves knowledge of the preimage of Hash and that
+// Y == Base^PreImage mod Modulus, the statement behind discrete-log
+// commitments and Schnorr-style proofs. The exponentiation is emulated by
+// square-and-multiply over the bits of the secret. The circuit does not check
+// that Modulus is prime; that is up to whoever chooses the public inputs.
+type ModExpHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Base     frontend.Variable `gnark:",public"`
+	Modulus  frontend.Variable `gnark:",public"`
+	Y        frontend.Variable `gnark:",public"`
+}
+
+func (circuit *ModExpHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	rc := rangecheck.New(api)
+	rc.Check(circuit.Modulus, ModExpModulusBits)
+	api.AssertIsLessOrEqual(api.Add(circuit.Base, 1), circuit.Modulus)
+
+	// ToBinary also constrains the secret to ModExpExponentBits bits.
+	bits := api.ToBinary(circuit.PreImage, ModExpExponentBits)
+
+	mulMod := func(a, b frontend.Variable) (frontend.Variable, error) {
+		_, r, err := divMod(api, api.Mul(a, b), circuit.Modulus, ModExpModulusBits, ModExpModulusBits)
+		return r, err
+	}
+
+	// 1 mod Modulus, which is 0 when Modulus is 1.
+	_, acc, err := divMod(api, 1, circuit.Modulus, 1, ModExpModulusBits)
+	if err != nil {
+		return err
+	}
+	for i := len(bits) - 1; i >= 0; i-- {
+		if acc, err = mulMod(acc, acc); err != nil {
+			return err
+		}
+		withBase, err := mulMod(acc, circuit.Base)
+		if err != nil {
+			return err
+		}
+		acc = api.Select(bits[i], withBase, acc)
+	}
+	api.AssertIsEqual(circuit.Y, acc)
+
+	return nil
+}
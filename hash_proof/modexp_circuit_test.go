@@ -0,0 +1,49 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestModExpHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit ModExpHashCircuit
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	t.Logf("ModExpHashCircuit constraints: %d", ccs.GetNbConstraints())
+
+	// The Mersenne prime 2^107 - 1.
+	modulus := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 107), big.NewInt(1))
+	base := big.NewInt(3)
+	preImage := new(big.Int).SetUint64(0xDEADBEEFCAFE)
+	y := new(big.Int).Exp(base, preImage, modulus)
+	hash, err := MiMCHash(ecc.BN254, preImage)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+
+	assert.ProverSucceeded(&circuit, &ModExpHashCircuit{
+		PreImage: preImage,
+		Hash:     hash,
+		Base:     base,
+		Modulus:  modulus,
+		Y:        y,
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverFailed(&circuit, &ModExpHashCircuit{
+		PreImage: preImage,
+		Hash:     hash,
+		Base:     base,
+		Modulus:  modulus,
+		Y:        new(big.Int).Exp(base, new(big.Int).Add(preImage, big.NewInt(1)), modulus),
+	}, test.WithCurves(ecc.BN254))
+}
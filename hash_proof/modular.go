@@ -0,0 +1,87 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+func init() {
+	solver.RegisterHint(divModHint, modInverseHint)
+}
+
+// divModHint computes q, r such that x = q*m + r and 0 <= r < m.
+func divModHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if inputs[1].Sign() == 0 {
+		outputs[0].SetUint64(0)
+		outputs[1].SetUint64(0)
+		return nil
+	}
+	outputs[0].DivMod(inputs[0], inputs[1], outputs[1])
+	return nil
+}
+
+// modInverseHint computes inv, q such that a*inv = q*m + 1. When a has no
+// inverse modulo m both outputs are zero and the caller's constraints fail.
+func modInverseHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	a, m := inputs[0], inputs[1]
+	inv := new(big.Int)
+	if m.Sign() == 0 || inv.ModInverse(a, m) == nil {
+		outputs[0].SetUint64(0)
+		outputs[1].SetUint64(0)
+		return nil
+	}
+	outputs[0].Set(inv)
+	outputs[1].Mul(a, inv).Sub(outputs[1], big.NewInt(1)).Div(outputs[1], m)
+	return nil
+}
+
+// divMod returns q and r with x = q*m + r and 0 <= r < m, treating x and m as
+// integers. q is range checked to qBits and r to rBits bits; the caller must
+// pick bounds for which q*m + r cannot wrap around the field, and must keep
+// m <= 2^rBits, so that r < m reduces to a range check of m - r - 1.
+func divMod(api frontend.API, x, m frontend.Variable, qBits, rBits int) (q, r frontend.Variable, err error) {
+	out, err := api.Compiler().NewHint(divModHint, 2, x, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, r = out[0], out[1]
+
+	rc := rangecheck.New(api)
+	rc.Check(q, qBits)
+	rc.Check(r, rBits)
+	rc.Check(api.Sub(m, api.Add(r, 1)), rBits)
+	api.AssertIsEqual(x, api.Add(api.Mul(q, m), r))
+
+	return q, r, nil
+}
+
+// modConstant returns x mod k for an arbitrary field element x and a constant
+// k. Unlike divMod it accepts x up to the field modulus p: the quotient is
+// bounded by (p-1)/k, and when it reaches that bound the remainder is bounded
+// by (p-1) mod k, so q*k + r cannot wrap around the field.
+func modConstant(api frontend.API, x frontend.Variable, k uint64) (frontend.Variable, error) {
+	p := api.Compiler().Field()
+	kBig := new(big.Int).SetUint64(k)
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+	qMax, rMax := new(big.Int).DivMod(pMinusOne, kBig, new(big.Int))
+
+	out, err := api.Compiler().NewHint(divModHint, 2, x, k)
+	if err != nil {
+		return nil, err
+	}
+	q, r := out[0], out[1]
+
+	rc := rangecheck.New(api)
+	rc.Check(q, qMax.BitLen())
+	rc.Check(r, kBig.BitLen())
+	api.AssertIsLessOrEqual(q, qMax)
+	api.AssertIsLessOrEqual(r, k-1)
+	atMax := api.IsZero(api.Sub(q, qMax))
+	api.AssertIsLessOrEqual(api.Mul(atMax, r), rMax)
+	api.AssertIsEqual(x, api.Add(api.Mul(q, k), r))
+
+	return r, nil
+}
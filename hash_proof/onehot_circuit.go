@@ -0,0 +1,44 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+// OneHotMaxLength is the capacity of the public array of
+// OneHotMembershipCircuit.
+const OneHotMaxLength = 8
+
+// OneHotMembershipCircuit proves that the secret equals one of the first
+// Length entries of the public array Elements, without revealing which. The
+// secret one-hot Selector picks the entry: its components are bits summing to
+// one, set only below Length, and its inner product with Elements is the
+// secret.
+type OneHotMembershipCircuit struct {
+	PreImage frontend.Variable                  `gnark:",secret"`
+	Selector [OneHotMaxLength]frontend.Variable `gnark:",secret"`
+	Elements [OneHotMaxLength]frontend.Variable `gnark:",public"`
+	Length   frontend.Variable                  `gnark:",public"`
+}
+
+func (circuit *OneHotMembershipCircuit) Define(api frontend.API) error {
+	api.AssertIsLessOrEqual(circuit.Length, OneHotMaxLength)
+
+	// active is 1 while the index is below Length and drops to 0 for good
+	// once the index reaches it.
+	active := frontend.Variable(1)
+	sum := frontend.Variable(0)
+	product := frontend.Variable(0)
+	for i, sel := range circuit.Selector {
+		active = api.Mul(active, api.Sub(1, api.IsZero(api.Sub(circuit.Length, i))))
+
+		api.AssertIsBoolean(sel)
+		api.AssertIsEqual(api.Mul(sel, api.Sub(1, active)), 0)
+
+		sum = api.Add(sum, sel)
+		product = api.Add(product, api.Mul(sel, circuit.Elements[i]))
+	}
+	api.AssertIsEqual(sum, 1)
+	api.AssertIsEqual(product, circuit.PreImage)
+
+	return nil
+}
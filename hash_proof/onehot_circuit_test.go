@@ -0,0 +1,50 @@
+package hash_proof
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestOneHotMembershipCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	whitelist := []int{12, 35, 57, 91, 100}
+	assignment := func(secret int, selected ...int) *OneHotMembershipCircuit {
+		a := &OneHotMembershipCircuit{PreImage: secret, Length: len(whitelist)}
+		for i := range a.Elements {
+			a.Elements[i] = 0
+			a.Selector[i] = 0
+		}
+		for i, e := range whitelist {
+			a.Elements[i] = e
+		}
+		for _, i := range selected {
+			a.Selector[i] = 1
+		}
+		return a
+	}
+
+	var circuit OneHotMembershipCircuit
+	assert.ProverSucceeded(&circuit, assignment(35, 1), test.WithCurves(ecc.BN254))
+	assert.ProverSucceeded(&circuit, assignment(100, 4), test.WithCurves(ecc.BN254))
+
+	// Selecting the wrong element, no element or two elements fails.
+	assert.ProverFailed(&circuit, assignment(35, 2), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(35), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(47, 0, 1), test.WithCurves(ecc.BN254))
+
+	// A non-binary selector scaling an element onto the secret fails.
+	scaled := assignment(24)
+	scaled.Selector[0] = 2
+	assert.ProverFailed(&circuit, scaled, test.WithCurves(ecc.BN254))
+
+	// Entries past Length are padding and cannot be selected.
+	padding := assignment(0, 6)
+	assert.ProverFailed(&circuit, padding, test.WithCurves(ecc.BN254))
+
+	tooLong := assignment(35, 1)
+	tooLong.Length = OneHotMaxLength + 1
+	assert.ProverFailed(&circuit, tooLong, test.WithCurves(ecc.BN254))
+}
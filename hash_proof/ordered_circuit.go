@@ -0,0 +1,32 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// OrderedHashCircuit proves knowledge of the preimage of Hash and that Hash
+// is strictly less than the public Next, as integers, so the commitment sits
+// before its neighbour in a sorted list of hashes.
+type OrderedHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Next     frontend.Variable `gnark:",public"`
+}
+
+func (circuit *OrderedHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	hash := hFunc.Sum()
+	api.AssertIsEqual(circuit.Hash, hash)
+
+	// Cmp works on the full field width, unlike adding one before
+	// AssertIsLessOrEqual, which would wrap around for the largest hash.
+	api.AssertIsEqual(api.Cmp(hash, circuit.Next), -1)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestOrderedHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit OrderedHashCircuit
+
+	hashes := make([]*big.Int, 2)
+	for i, secret := range []int64{35, 36} {
+		h, err := MiMCHash(ecc.BN254, big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+		hashes[i] = h
+	}
+	lo, hi := 0, 1
+	if hashes[0].Cmp(hashes[1]) > 0 {
+		lo, hi = 1, 0
+	}
+	secrets := []int64{35, 36}
+
+	assert.ProverSucceeded(&circuit, &OrderedHashCircuit{
+		PreImage: secrets[lo],
+		Hash:     hashes[lo],
+		Next:     hashes[hi],
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverFailed(&circuit, &OrderedHashCircuit{
+		PreImage: secrets[hi],
+		Hash:     hashes[hi],
+		Next:     hashes[lo],
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverFailed(&circuit, &OrderedHashCircuit{
+		PreImage: secrets[lo],
+		Hash:     hashes[lo],
+		Next:     hashes[lo],
+	}, test.WithCurves(ecc.BN254))
+}
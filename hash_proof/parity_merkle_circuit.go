@@ -0,0 +1,143 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// MerkleDepth is the depth of the Merkle trees the inclusion circuits accept,
+// i.e. they hold 2^MerkleDepth leaves. Leaves are MiMC(secret) and inner
+// nodes the canonical hash of their left and right children.
+const MerkleDepth = 4
+
+var merkleNodeSchema = []string{"left", "right"}
+
+// PositionParityMerkleCircuit proves that MiMC(PreImage) is a leaf of the tree
+// with the public Root, and publishes only whether the leaf index is odd.
+// PathIndices holds the index bits from the leaf up, 1 meaning the node is a
+// right child, so the parity is the first of them.
+type PositionParityMerkleCircuit struct {
+	PreImage    frontend.Variable              `gnark:",secret"`
+	Siblings    [MerkleDepth]frontend.Variable `gnark:",secret"`
+	PathIndices [MerkleDepth]frontend.Variable `gnark:",secret"`
+	Root        frontend.Variable              `gnark:",public"`
+	Parity      frontend.Variable              `gnark:",public"`
+}
+
+func (circuit *PositionParityMerkleCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	node, err := merkleRoot(api, hFunc.Sum(), circuit.Siblings[:], circuit.PathIndices[:])
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.Root, node)
+
+	api.AssertIsEqual(circuit.Parity, circuit.PathIndices[0])
+
+	return nil
+}
+
+// merkleRoot hashes leaf up the path given by siblings and pathIndices, from
+// the leaf up with 1 meaning the node is a right child, and returns the root.
+func merkleRoot(api frontend.API, leaf frontend.Variable, siblings, pathIndices []frontend.Variable) (frontend.Variable, error) {
+	if len(pathIndices) != len(siblings) {
+		return nil, fmt.Errorf("%d path indices for %d siblings", len(pathIndices), len(siblings))
+	}
+
+	node := leaf
+	for i, sibling := range siblings {
+		isRight := pathIndices[i]
+		api.AssertIsBoolean(isRight)
+
+		var err error
+		node, err = canonicalHash(api, api.Select(isRight, sibling, node), api.Select(isRight, node, sibling))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// merkleNode is the native counterpart of an inner node of merkleRoot.
+func merkleNode(curveID ecc.ID, left, right *big.Int) (*big.Int, error) {
+	return CanonicalHash(curveID, map[string]*big.Int{
+		"left":  left,
+		"right": right,
+	}, merkleNodeSchema)
+}
+
+// MerkleRoot returns the root of the tree whose leaves are the MiMC hashes of
+// secrets. There must be exactly 2^MerkleDepth secrets.
+func MerkleRoot(curveID ecc.ID, secrets []*big.Int) (*big.Int, error) {
+	levels, err := merkleLevels(curveID, secrets)
+	if err != nil {
+		return nil, err
+	}
+	return levels[MerkleDepth][0], nil
+}
+
+// MerklePath returns the siblings of the leaf at index, from the leaf up.
+func MerklePath(curveID ecc.ID, secrets []*big.Int, index int) ([MerkleDepth]*big.Int, error) {
+	var siblings [MerkleDepth]*big.Int
+	if index < 0 || index >= len(secrets) {
+		return siblings, fmt.Errorf("leaf index %d out of range", index)
+	}
+
+	levels, err := merkleLevels(curveID, secrets)
+	if err != nil {
+		return siblings, err
+	}
+	for i := range siblings {
+		siblings[i] = levels[i][index^1]
+		index >>= 1
+	}
+	return siblings, nil
+}
+
+func merkleLevels(curveID ecc.ID, secrets []*big.Int) ([][]*big.Int, error) {
+	if len(secrets) != 1<<MerkleDepth {
+		return nil, fmt.Errorf("expected %d leaves, got %d", 1<<MerkleDepth, len(secrets))
+	}
+
+	leaves := make([]*big.Int, len(secrets))
+	for i, s := range secrets {
+		leaf, err := MiMCHash(curveID, s)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = leaf
+	}
+	return merkleLevelsFromLeaves(curveID, leaves)
+}
+
+// merkleLevelsFromLeaves hashes a full level of leaves, a power of two of
+// them, up to the root. levels[0] are the leaves and the last level holds
+// only the root.
+func merkleLevelsFromLeaves(curveID ecc.ID, leaves []*big.Int) ([][]*big.Int, error) {
+	if len(leaves) == 0 || len(leaves)&(len(leaves)-1) != 0 {
+		return nil, fmt.Errorf("leaf count %d is not a power of two", len(leaves))
+	}
+
+	levels := [][]*big.Int{leaves}
+	for below := leaves; len(below) > 1; below = levels[len(levels)-1] {
+		above := make([]*big.Int, len(below)/2)
+		for i := range above {
+			node, err := merkleNode(curveID, below[2*i], below[2*i+1])
+			if err != nil {
+				return nil, err
+			}
+			above[i] = node
+		}
+		levels = append(levels, above)
+	}
+	return levels, nil
+}
@@ -0,0 +1,51 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestPositionParityMerkleCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	secrets := make([]*big.Int, 1<<MerkleDepth)
+	for i := range secrets {
+		secrets[i] = big.NewInt(int64(100 + i))
+	}
+	root, err := MerkleRoot(ecc.BN254, secrets)
+	if err != nil {
+		t.Fatalf("Failed to compute Merkle root: %v", err)
+	}
+
+	assignment := func(index, parity int) *PositionParityMerkleCircuit {
+		siblings, err := MerklePath(ecc.BN254, secrets, index)
+		if err != nil {
+			t.Fatalf("Failed to compute Merkle path: %v", err)
+		}
+		a := &PositionParityMerkleCircuit{
+			PreImage: secrets[index],
+			Root:     root,
+			Parity:   parity,
+		}
+		for i := range siblings {
+			a.Siblings[i] = siblings[i]
+			a.PathIndices[i] = (index >> i) & 1
+		}
+		return a
+	}
+
+	var circuit PositionParityMerkleCircuit
+	for _, index := range []int{6, 11} {
+		parity := index & 1
+		assert.ProverSucceeded(&circuit, assignment(index, parity), test.WithCurves(ecc.BN254))
+		assert.ProverFailed(&circuit, assignment(index, 1-parity), test.WithCurves(ecc.BN254))
+	}
+
+	// Claiming the other parity by flipping the lowest path bit breaks inclusion.
+	flipped := assignment(6, 1)
+	flipped.PathIndices[0] = 1
+	assert.ProverFailed(&circuit, flipped, test.WithCurves(ecc.BN254))
+}
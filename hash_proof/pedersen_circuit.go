@@ -0,0 +1,119 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bn254tedwards "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// pedersenChunkBits is the width of the low chunk of the secret. Both chunks
+// are below the order of the Baby Jubjub subgroup, so the hash is injective.
+const pedersenChunkBits = 128
+
+// pedersenGenerators are the two generators of the Pedersen hash on Baby
+// Jubjub: the curve's base point and a point derived by hashing to the curve,
+// whose discrete logarithm with respect to the base point is unknown.
+var pedersenGenerators = func() [2]bn254tedwards.PointAffine {
+	params := bn254tedwards.GetEdwardsCurve()
+	return [2]bn254tedwards.PointAffine{params.Base, hashToBabyJubjub("hash_proof/pedersen/1")}
+}()
+
+// hashToBabyJubjub maps seed to a point of the prime-order subgroup by
+// try-and-increment on the y coordinate, then clearing the cofactor.
+func hashToBabyJubjub(seed string) bn254tedwards.PointAffine {
+	params := bn254tedwards.GetEdwardsCurve()
+	var one fr.Element
+	one.SetOne()
+
+	for ctr := 0; ; ctr++ {
+		digest := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", seed, ctr)))
+
+		// a*x^2 + y^2 = 1 + d*x^2*y^2, so x^2 = (1 - y^2) / (a - d*y^2).
+		var p bn254tedwards.PointAffine
+		p.Y.SetBytes(digest[:])
+		var y2, num, den, x2 fr.Element
+		y2.Square(&p.Y)
+		num.Sub(&one, &y2)
+		den.Mul(&params.D, &y2)
+		den.Sub(&params.A, &den)
+		if den.IsZero() {
+			continue
+		}
+		x2.Div(&num, &den)
+		if p.X.Sqrt(&x2) == nil {
+			continue
+		}
+
+		var cofactor big.Int
+		params.Cofactor.BigInt(&cofactor)
+		p.ScalarMultiplication(&p, &cofactor)
+		if !p.IsZero() {
+			return p
+		}
+	}
+}
+
+// PedersenMiMCCircuit proves knowledge of a secret whose MiMC hash is Hash
+// and whose Pedersen hash on Baby Jubjub, the twisted Edwards curve embedded
+// in BN254, is the point (PedersenX, PedersenY). It only compiles over BN254.
+type PedersenMiMCCircuit struct {
+	PreImage  frontend.Variable `gnark:",secret"`
+	Hash      frontend.Variable `gnark:",public"`
+	PedersenX frontend.Variable `gnark:",public"`
+	PedersenY frontend.Variable `gnark:",public"`
+}
+
+func (circuit *PedersenMiMCCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+
+	bits := api.ToBinary(circuit.PreImage)
+	lo := api.FromBinary(bits[:pedersenChunkBits]...)
+	hi := api.FromBinary(bits[pedersenChunkBits:]...)
+
+	var generators [2]twistededwards.Point
+	for i, g := range pedersenGenerators {
+		generators[i] = twistededwards.Point{X: g.X.String(), Y: g.Y.String()}
+	}
+	commitment := curve.DoubleBaseScalarMul(generators[0], generators[1], lo, hi)
+	api.AssertIsEqual(circuit.PedersenX, commitment.X)
+	api.AssertIsEqual(circuit.PedersenY, commitment.Y)
+
+	return nil
+}
+
+// PedersenHash computes out of circuit the Pedersen hash checked by
+// PedersenMiMCCircuit: lo*G + hi*H for the low 128 bits and the remaining
+// high bits of secret.
+func PedersenHash(secret *big.Int) (x, y *big.Int, err error) {
+	if secret.Sign() < 0 || secret.Cmp(fr.Modulus()) >= 0 {
+		return nil, nil, fmt.Errorf("secret is out of range for bn254")
+	}
+
+	lo := new(big.Int).Mod(secret, new(big.Int).Lsh(big.NewInt(1), pedersenChunkBits))
+	hi := new(big.Int).Rsh(secret, pedersenChunkBits)
+
+	var p, q bn254tedwards.PointAffine
+	p.ScalarMultiplication(&pedersenGenerators[0], lo)
+	q.ScalarMultiplication(&pedersenGenerators[1], hi)
+	p.Add(&p, &q)
+
+	return p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)), nil
+}
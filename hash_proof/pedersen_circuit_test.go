@@ -0,0 +1,64 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestPedersenMiMCCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit PedersenMiMCCircuit
+
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	x, y, err := PedersenHash(big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to compute Pedersen hash: %v", err)
+	}
+
+	assert.ProverSucceeded(&circuit, &PedersenMiMCCircuit{
+		PreImage:  35,
+		Hash:      hash,
+		PedersenX: x,
+		PedersenY: y,
+	}, test.WithCurves(ecc.BN254))
+
+	// A secret spanning both chunks exercises the second generator.
+	large := new(big.Int).Lsh(big.NewInt(35), 200)
+	largeHash, err := MiMCHash(ecc.BN254, large)
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	lx, ly, err := PedersenHash(large)
+	if err != nil {
+		t.Fatalf("Failed to compute Pedersen hash: %v", err)
+	}
+	assert.ProverSucceeded(&circuit, &PedersenMiMCCircuit{
+		PreImage:  large,
+		Hash:      largeHash,
+		PedersenX: lx,
+		PedersenY: ly,
+	}, test.WithCurves(ecc.BN254))
+
+	// The MiMC hash alone does not satisfy the circuit.
+	assert.ProverFailed(&circuit, &PedersenMiMCCircuit{
+		PreImage:  35,
+		Hash:      hash,
+		PedersenX: lx,
+		PedersenY: ly,
+	}, test.WithCurves(ecc.BN254))
+}
+
+func TestPedersenGenerators(t *testing.T) {
+	for i, g := range pedersenGenerators {
+		if !g.IsOnCurve() || g.IsZero() {
+			t.Fatalf("Generator %d is not a valid curve point", i)
+		}
+	}
+	if pedersenGenerators[0].Equal(&pedersenGenerators[1]) {
+		t.Fatal("Pedersen generators must be distinct")
+	}
+}
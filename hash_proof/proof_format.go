@@ -0,0 +1,152 @@
+package hash_proof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// ProofFormatVersion is the first byte of every proof produced by
+// SerializeProof. Version 1 is followed by the curve ID and gnark's raw
+// (uncompressed) proof encoding.
+const ProofFormatVersion byte = 1
+
+// SerializeProof encodes proof in the current versioned format.
+func SerializeProof(proof groth16.Proof) ([]byte, error) {
+	curveID := proof.CurveID()
+	if curveID > 0xff {
+		return nil, fmt.Errorf("curve %s does not fit the proof header", curveID)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(ProofFormatVersion)
+	buf.WriteByte(byte(curveID))
+	if _, err := proof.WriteRawTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DeserializeProof decodes a proof produced by SerializeProof. Older formats
+// must be upgraded with MigrateProof first.
+func DeserializeProof(data []byte) (groth16.Proof, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty proof")
+	}
+	if data[0] != ProofFormatVersion {
+		return nil, fmt.Errorf("unsupported proof format version %d (current is %d)", data[0], ProofFormatVersion)
+	}
+	if len(data) < 2 {
+		return nil, fmt.Errorf("proof header truncated")
+	}
+	return decodeRawProof(ecc.ID(data[1]), data[2:])
+}
+
+// MigrateProof upgrades a stored proof to the current format. Besides the
+// current version it accepts the unversioned raw BN254 encoding written by
+// earlier releases of the generator (the fullProofHex of
+// remix_proof_values.json).
+func MigrateProof(data []byte) ([]byte, error) {
+	if _, err := DeserializeProof(data); err == nil {
+		return data, nil
+	}
+
+	if proof, err := decodeRawProof(ecc.BN254, data); err == nil {
+		return SerializeProof(proof)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty proof")
+	}
+	return nil, fmt.Errorf("cannot migrate proof: unknown format version %d and not a legacy raw BN254 proof", data[0])
+}
+
+func decodeRawProof(curveID ecc.ID, data []byte) (groth16.Proof, error) {
+	if _, ok := mimcByCurve[curveID]; !ok {
+		return nil, fmt.Errorf("unsupported curve %s", curveID)
+	}
+
+	proof := groth16.NewProof(curveID)
+	n, err := proof.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s proof: %w", curveID, err)
+	}
+	if n != int64(len(data)) {
+		return nil, fmt.Errorf("%d trailing bytes after %s proof", int64(len(data))-n, curveID)
+	}
+	return proof, nil
+}
+
+// compressedProofMagic prefixes proofs written by SerializeProofCompressed.
+// It cannot be mistaken for a versioned proof, whose first byte is the
+// format version.
+var compressedProofMagic = []byte("ZKPZ")
+
+// maxProofBytes bounds the decompressed size of a compressed proof. The
+// largest versioned proof, over BW6-761 with a commitment, is 966 bytes.
+const maxProofBytes = 1024
+
+// SerializeProofCompressed writes proof in the current versioned format,
+// gzip-compressed behind a magic prefix.
+func SerializeProofCompressed(proof groth16.Proof, w io.Writer) error {
+	data, err := SerializeProof(proof)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(compressedProofMagic); err != nil {
+		return err
+	}
+	zw, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// DeserializeProofCompressed reads a proof written by
+// SerializeProofCompressed. It stops decompressing past the size of the
+// largest proof, so a small input cannot expand without bound.
+func DeserializeProofCompressed(r io.Reader) (groth16.Proof, error) {
+	magic := make([]byte, len(compressedProofMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, fmt.Errorf("reading compressed proof header: %w", err)
+	}
+	if !bytes.Equal(magic, compressedProofMagic) {
+		return nil, fmt.Errorf("not a compressed proof")
+	}
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(zr, maxProofBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxProofBytes {
+		return nil, fmt.Errorf("compressed proof expands beyond %d bytes", maxProofBytes)
+	}
+	return DeserializeProof(data)
+}
+
+// ProofCompressionRatio returns the size of proof in the versioned format
+// divided by its compressed size. Proofs are mostly uniformly random
+// coordinates, so a single proof compresses to about its own size, and the
+// gzip header can push the ratio slightly below 1.
+func ProofCompressionRatio(proof groth16.Proof) (float64, error) {
+	data, err := SerializeProof(proof)
+	if err != nil {
+		return 0, err
+	}
+	var buf bytes.Buffer
+	if err := SerializeProofCompressed(proof, &buf); err != nil {
+		return 0, err
+	}
+	return float64(len(data)) / float64(buf.Len()), nil
+}
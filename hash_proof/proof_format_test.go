@@ -0,0 +1,124 @@
+package hash_proof_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestSerializeProofVersioning(t *testing.T) {
+	_, _, vk, proof, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+
+	data, err := hash_proof.SerializeProof(proof)
+	if err != nil {
+		t.Fatalf("Failed to serialize proof: %v", err)
+	}
+	if data[0] != hash_proof.ProofFormatVersion {
+		t.Fatalf("Unexpected version byte %d", data[0])
+	}
+
+	loaded, err := hash_proof.DeserializeProof(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize proof: %v", err)
+	}
+	if err := groth16.Verify(loaded, vk, publicWitness); err != nil {
+		t.Fatalf("Failed to verify deserialized proof: %v", err)
+	}
+
+	migrated, err := hash_proof.MigrateProof(data)
+	if err != nil {
+		t.Fatalf("Failed to migrate current proof: %v", err)
+	}
+	if !bytes.Equal(migrated, data) {
+		t.Fatal("Migrating a current proof must not change it")
+	}
+
+	// Earlier generator releases stored the raw, unversioned encoding.
+	var legacy bytes.Buffer
+	if _, err := proof.WriteRawTo(&legacy); err != nil {
+		t.Fatalf("Failed to serialize legacy proof: %v", err)
+	}
+	migrated, err = hash_proof.MigrateProof(legacy.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to migrate legacy proof: %v", err)
+	}
+	loaded, err = hash_proof.DeserializeProof(migrated)
+	if err != nil {
+		t.Fatalf("Failed to deserialize migrated proof: %v", err)
+	}
+	if err := groth16.Verify(loaded, vk, publicWitness); err != nil {
+		t.Fatalf("Failed to verify migrated proof: %v", err)
+	}
+}
+
+func TestDeserializeProofUnknownVersion(t *testing.T) {
+	data := append([]byte{0xff, byte(ecc.BN254)}, make([]byte, 64)...)
+
+	_, err := hash_proof.DeserializeProof(data)
+	if err == nil || !strings.Contains(err.Error(), "unsupported proof format version 255") {
+		t.Fatalf("Expected a version error, got %v", err)
+	}
+
+	_, err = hash_proof.MigrateProof(data)
+	if err == nil || !strings.Contains(err.Error(), "unknown format version 255") {
+		t.Fatalf("Expected a migration error, got %v", err)
+	}
+}
+
+func TestSerializeProofCompressed(t *testing.T) {
+	_, _, vk, proof, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+
+	var buf bytes.Buffer
+	if err := hash_proof.SerializeProofCompressed(proof, &buf); err != nil {
+		t.Fatalf("Failed to serialize compressed proof: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("ZKPZ")) {
+		t.Fatalf("Missing magic prefix: %x", buf.Bytes()[:4])
+	}
+
+	ratio, err := hash_proof.ProofCompressionRatio(proof)
+	if err != nil {
+		t.Fatalf("Failed to compute compression ratio: %v", err)
+	}
+	t.Logf("Compressed proof: %d bytes, ratio %.2f", buf.Len(), ratio)
+
+	loaded, err := hash_proof.DeserializeProofCompressed(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to deserialize compressed proof: %v", err)
+	}
+	if err := groth16.Verify(loaded, vk, publicWitness); err != nil {
+		t.Fatalf("Failed to verify decompressed proof: %v", err)
+	}
+
+	data, err := hash_proof.SerializeProof(proof)
+	if err != nil {
+		t.Fatalf("Failed to serialize proof: %v", err)
+	}
+	if _, err := hash_proof.DeserializeProofCompressed(bytes.NewReader(data)); err == nil {
+		t.Fatal("Expected an uncompressed proof to be rejected")
+	}
+}
+
+func TestDeserializeProofCompressedLimit(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("ZKPZ")
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(make([]byte, 1<<20)); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+
+	_, err := hash_proof.DeserializeProofCompressed(&buf)
+	if err == nil || !strings.Contains(err.Error(), "expands beyond") {
+		t.Fatalf("Expected an oversized proof to be rejected, got %v", err)
+	}
+}
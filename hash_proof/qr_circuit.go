@@ -0,0 +1,70 @@
+package hash_proof
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+const (
+	// QRPrimeBits bounds the public prime of QRHashCircuit so that the
+	// square of a residue stays below the field modulus.
+	QRPrimeBits = 120
+	// qrLimbBits is the width of the hash limbs reduced one at a time.
+	qrLimbBits = 64
+)
+
+// QRHashCircuit proves knowledge of the preimage of Hash and that Hash mod
+// Prime is a quadratic residue, by exhibiting a secret Root with
+// Root² ≡ Hash (mod Prime).
+//
+// Arithmetic mod Prime is emulated in the native field with divMod: a hint
+// supplies quotient and remainder, which are range checked so that q*p + r
+// cannot wrap around the field, and the division identity is asserted. The
+// full-width hash cannot be reduced that way, so it is decomposed into
+// canonical bits and reduced Horner-style one 64-bit limb at a time. On
+// BN254 this costs about 1.2k constraints, against 331 for HashCircuit. As
+// with ModExpHashCircuit, Prime is not checked to be prime.
+type QRHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Root     frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Prime    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *QRHashCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	rc := rangecheck.New(api)
+	rc.Check(circuit.Prime, QRPrimeBits)
+
+	// Hash mod Prime, from the most significant limb down. Each step keeps
+	// acc*2^64 + limb below 2^(QRPrimeBits+64).
+	bits := api.ToBinary(circuit.Hash)
+	var acc frontend.Variable = 0
+	for hi := len(bits); hi > 0; hi -= qrLimbBits {
+		lo := max(hi-qrLimbBits, 0)
+		limb := api.FromBinary(bits[lo:hi]...)
+		shifted := api.Add(api.Mul(acc, new(big.Int).Lsh(big.NewInt(1), uint(hi-lo))), limb)
+		if _, acc, err = divMod(api, shifted, circuit.Prime, qrLimbBits, QRPrimeBits); err != nil {
+			return err
+		}
+	}
+
+	rc.Check(circuit.Root, QRPrimeBits)
+	_, square, err := divMod(api, api.Mul(circuit.Root, circuit.Root), circuit.Prime, QRPrimeBits, QRPrimeBits)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(square, acc)
+
+	return nil
+}
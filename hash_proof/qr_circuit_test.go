@@ -0,0 +1,71 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+type qrCase struct {
+	secret     int64
+	hash, root *big.Int
+}
+
+func (c *qrCase) assignment(prime *big.Int) *QRHashCircuit {
+	return &QRHashCircuit{PreImage: c.secret, Root: c.root, Hash: c.hash, Prime: prime}
+}
+
+func TestQRHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit QRHashCircuit
+
+	// The Mersenne primes 2^61 - 1 and 2^107 - 1.
+	for _, bits := range []uint{61, 107} {
+		prime := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1))
+
+		var residue, nonResidue *qrCase
+		for secret := int64(1); residue == nil || nonResidue == nil; secret++ {
+			hash, err := MiMCHash(ecc.BN254, big.NewInt(secret))
+			if err != nil {
+				t.Fatalf("Failed to compute MiMC hash: %v", err)
+			}
+			reduced := new(big.Int).Mod(hash, prime)
+			pair := &qrCase{secret: secret, hash: hash}
+			if root := new(big.Int).ModSqrt(reduced, prime); root != nil {
+				if residue == nil {
+					pair.root = root
+					residue = pair
+				}
+			} else if nonResidue == nil {
+				nonResidue = pair
+			}
+		}
+
+		assert.ProverSucceeded(&circuit, residue.assignment(prime), test.WithCurves(ecc.BN254))
+
+		// The other square root works too; a wrong one does not.
+		negated := residue.assignment(prime)
+		negated.Root = new(big.Int).Sub(prime, residue.root)
+		assert.ProverSucceeded(&circuit, negated, test.WithCurves(ecc.BN254))
+		wrong := residue.assignment(prime)
+		wrong.Root = new(big.Int).Add(residue.root, big.NewInt(1))
+		assert.ProverFailed(&circuit, wrong, test.WithCurves(ecc.BN254))
+
+		// No root exists for a non-residue; try a few.
+		for _, root := range []int64{0, 1, 2, 12345} {
+			nonResidue.root = big.NewInt(root)
+			assert.ProverFailed(&circuit, nonResidue.assignment(prime), test.WithCurves(ecc.BN254))
+		}
+	}
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	t.Logf("QRHashCircuit constraints: %d", ccs.GetNbConstraints())
+}
@@ -0,0 +1,131 @@
+package hash_proof
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// rotationDomain separates rotation nullifiers from every other hash of the
+// old secret. It is the big-endian integer of the ASCII bytes "rotate".
+var rotationDomain = new(big.Int).SetBytes([]byte("rotate"))
+
+var rotationNullifierSchema = []string{"secret", "domain"}
+
+// RotationCircuit proves that the owner of OldCommitment re-committed to
+// NewCommitment, revealing neither secret. RotationNullifier depends only on
+// the old secret, so the old commitment can be retired exactly once.
+type RotationCircuit struct {
+	OldCommitment     frontend.Variable `gnark:",public"`
+	NewCommitment     frontend.Variable `gnark:",public"`
+	RotationNullifier frontend.Variable `gnark:",public"`
+	OldSecret         frontend.Variable `gnark:",secret"`
+	NewSecret         frontend.Variable `gnark:",secret"`
+}
+
+func (circuit *RotationCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.OldSecret)
+	api.AssertIsEqual(circuit.OldCommitment, hFunc.Sum())
+
+	hFunc.Reset()
+	hFunc.Write(circuit.NewSecret)
+	api.AssertIsEqual(circuit.NewCommitment, hFunc.Sum())
+
+	nullifier, err := canonicalHash(api, circuit.OldSecret, rotationDomain)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.RotationNullifier, nullifier)
+
+	return nil
+}
+
+// RotationNullifier computes the nullifier retiring the commitment of
+// oldSecret.
+func RotationNullifier(curveID ecc.ID, oldSecret *big.Int) (*big.Int, error) {
+	return CanonicalHash(curveID, map[string]*big.Int{
+		"secret": oldSecret,
+		"domain": rotationDomain,
+	}, rotationNullifierSchema)
+}
+
+// NewRotationAssignment fills a RotationCircuit assignment from the two
+// secrets.
+func NewRotationAssignment(curveID ecc.ID, oldSecret, newSecret *big.Int) (*RotationCircuit, error) {
+	oldCommitment, err := MiMCHash(curveID, oldSecret)
+	if err != nil {
+		return nil, err
+	}
+	newCommitment, err := MiMCHash(curveID, newSecret)
+	if err != nil {
+		return nil, err
+	}
+	nullifier, err := RotationNullifier(curveID, oldSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotationCircuit{
+		OldCommitment:     oldCommitment,
+		NewCommitment:     newCommitment,
+		RotationNullifier: nullifier,
+		OldSecret:         oldSecret,
+		NewSecret:         newSecret,
+	}, nil
+}
+
+const rotationWrapperSolidity = `// SPDX-License-Identifier: MIT
+// Code generated by hash_proof. DO NOT EDIT.
+pragma solidity ^0.8.0;
+
+/// @dev The gnark-exported verifier for RotationCircuit. It reverts when the
+/// proof is invalid.
+interface IRotationVerifier {
+    function verifyProof(uint256[8] calldata proof, uint256[3] calldata input) external view;
+}
+
+/// @notice Retires a commitment once its owner proves a rotation to a new one.
+contract CommitmentRotation {
+    IRotationVerifier public immutable verifier;
+
+    mapping(uint256 => bool) public retired;
+    mapping(uint256 => bool) public usedNullifiers;
+
+    event CommitmentRotated(uint256 indexed oldCommitment, uint256 indexed newCommitment, uint256 nullifier);
+
+    error CommitmentAlreadyRetired();
+    error NullifierAlreadyUsed();
+
+    constructor(address verifier_) {
+        verifier = IRotationVerifier(verifier_);
+    }
+
+    /// @param input Public inputs in circuit order: old commitment, new
+    /// commitment, rotation nullifier.
+    function rotate(uint256[8] calldata proof, uint256[3] calldata input) external {
+        if (retired[input[0]]) revert CommitmentAlreadyRetired();
+        if (usedNullifiers[input[2]]) revert NullifierAlreadyUsed();
+
+        verifier.verifyProof(proof, input);
+
+        retired[input[0]] = true;
+        usedNullifiers[input[2]] = true;
+        emit CommitmentRotated(input[0], input[1], input[2]);
+    }
+}
+`
+
+// ExportRotationWrapperSolidity writes a contract that calls the exported
+// RotationCircuit verifier and marks the old commitment retired on success.
+func ExportRotationWrapperSolidity(w io.Writer) error {
+	_, err := io.WriteString(w, rotationWrapperSolidity)
+	return err
+}
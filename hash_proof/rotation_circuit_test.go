@@ -0,0 +1,96 @@
+package hash_proof
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestRotationCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit RotationCircuit
+
+	assignment, err := NewRotationAssignment(ecc.BN254, big.NewInt(35), big.NewInt(36))
+	if err != nil {
+		t.Fatalf("Failed to create assignment: %v", err)
+	}
+	assert.ProverSucceeded(&circuit, assignment, test.WithCurves(ecc.BN254))
+
+	other, err := MiMCHash(ecc.BN254, big.NewInt(37))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	mismatched := *assignment
+	mismatched.NewCommitment = other
+	assert.ProverFailed(&circuit, &mismatched, test.WithCurves(ecc.BN254))
+
+	forged := *assignment
+	forged.RotationNullifier = other
+	assert.ProverFailed(&circuit, &forged, test.WithCurves(ecc.BN254))
+}
+
+func TestRotationNullifier(t *testing.T) {
+	first, err := NewRotationAssignment(ecc.BN254, big.NewInt(35), big.NewInt(36))
+	if err != nil {
+		t.Fatalf("Failed to create assignment: %v", err)
+	}
+	second, err := NewRotationAssignment(ecc.BN254, big.NewInt(35), big.NewInt(99))
+	if err != nil {
+		t.Fatalf("Failed to create assignment: %v", err)
+	}
+
+	n1 := first.RotationNullifier.(*big.Int)
+	n2 := second.RotationNullifier.(*big.Int)
+	if n1.Cmp(n2) != 0 {
+		t.Fatal("Rotating the same old secret twice must yield the same nullifier")
+	}
+	if n1.Cmp(first.OldCommitment.(*big.Int)) == 0 {
+		t.Fatal("Nullifier must differ from the old commitment")
+	}
+
+	third, err := NewRotationAssignment(ecc.BN254, big.NewInt(36), big.NewInt(99))
+	if err != nil {
+		t.Fatalf("Failed to create assignment: %v", err)
+	}
+	if n1.Cmp(third.RotationNullifier.(*big.Int)) == 0 {
+		t.Fatal("Different old secrets must yield different nullifiers")
+	}
+}
+
+func TestExportRotationWrapperSolidity(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportRotationWrapperSolidity(&buf); err != nil {
+		t.Fatalf("Failed to export wrapper: %v", err)
+	}
+
+	code := buf.String()
+	for _, want := range []string{
+		"contract CommitmentRotation",
+		"verifier.verifyProof(proof, input);",
+		"retired[input[0]] = true;",
+		"if (usedNullifiers[input[2]]) revert NullifierAlreadyUsed();",
+	} {
+		if !strings.Contains(code, want) {
+			t.Fatalf("Wrapper is missing %q", want)
+		}
+	}
+
+	if _, err := exec.LookPath("solc"); err != nil {
+		t.Skip("solc not installed")
+	}
+	path := filepath.Join(t.TempDir(), "CommitmentRotation.sol")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write wrapper: %v", err)
+	}
+	if err := CompileSolidity(path); err != nil {
+		t.Fatalf("Wrapper does not compile: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// ShardHashCircuit proves knowledge of the preimage of Hash and that Shard is
+// that hash reduced modulo the compile-time shard count, giving verifiable
+// consistent hashing of hidden identities.
+type ShardHashCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Hash     frontend.Variable `gnark:",public"`
+	Shard    frontend.Variable `gnark:",public"`
+
+	ShardCount uint64 `gnark:"-"`
+}
+
+// NewShardHashCircuit returns a circuit definition for k shards.
+func NewShardHashCircuit(k uint64) *ShardHashCircuit {
+	return &ShardHashCircuit{ShardCount: k}
+}
+
+func (circuit *ShardHashCircuit) Define(api frontend.API) error {
+	if circuit.ShardCount < 2 {
+		return fmt.Errorf("shard count must be at least 2, got %d", circuit.ShardCount)
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	hash := hFunc.Sum()
+	api.AssertIsEqual(circuit.Hash, hash)
+
+	shard, err := modConstant(api, hash, circuit.ShardCount)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(circuit.Shard, shard)
+
+	return nil
+}
+
+// ShardOf returns the hash of secret and the shard it maps to among k shards.
+func ShardOf(curveID ecc.ID, secret *big.Int, k uint64) (hash, shard *big.Int, err error) {
+	if k < 2 {
+		return nil, nil, fmt.Errorf("shard count must be at least 2, got %d", k)
+	}
+	hash, err = MiMCHash(curveID, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hash, new(big.Int).Mod(hash, new(big.Int).SetUint64(k)), nil
+}
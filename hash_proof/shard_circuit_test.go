@@ -0,0 +1,47 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestShardHashCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const shards = 16
+	circuit := NewShardHashCircuit(shards)
+
+	hash, shard, err := ShardOf(ecc.BN254, big.NewInt(35), shards)
+	if err != nil {
+		t.Fatalf("Failed to compute shard: %v", err)
+	}
+
+	expected := new(big.Int).Mod(hash, big.NewInt(shards))
+	if shard.Cmp(expected) != 0 {
+		t.Fatalf("Shard %s does not match hash mod %d = %s", shard, shards, expected)
+	}
+
+	assert.ProverSucceeded(circuit, &ShardHashCircuit{
+		PreImage: 35,
+		Hash:     hash,
+		Shard:    shard,
+	}, test.WithCurves(ecc.BN254))
+
+	tampered := new(big.Int).Add(shard, big.NewInt(1))
+	tampered.Mod(tampered, big.NewInt(shards))
+	assert.ProverFailed(circuit, &ShardHashCircuit{
+		PreImage: 35,
+		Hash:     hash,
+		Shard:    tampered,
+	}, test.WithCurves(ecc.BN254))
+
+	// A remainder congruent to the shard but out of range is rejected too.
+	assert.ProverFailed(circuit, &ShardHashCircuit{
+		PreImage: 35,
+		Hash:     hash,
+		Shard:    new(big.Int).Add(shard, big.NewInt(shards)),
+	}, test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,120 @@
+package hash_proof_test
+
+import (
+	"flag"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+var (
+	soakDuration = flag.Duration("soak", 0, "run the prove/verify soak test for this long")
+	soakMaxP99   = flag.Duration("soak-p99", 10*time.Second, "fail the soak test if the p99 prove+verify latency exceeds this")
+	soakMaxHeap  = flag.Uint64("soak-heap-growth", 16<<20, "fail the soak test if the in-use heap grows by more than this many bytes")
+)
+
+// heapInuse returns the bytes of in-use heap spans after a full collection,
+// so that garbage awaiting collection does not count as growth.
+func heapInuse() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapInuse
+}
+
+// TestSoak proves and verifies concurrently with one set of keys, as a
+// proving server does, and checks for errors, latency outliers and leaked
+// goroutines or heap. Without -soak it runs for a moment so CI, typically under
+// -race, still exercises the harness.
+func TestSoak(t *testing.T) {
+	duration := *soakDuration
+	if duration == 0 {
+		duration = 200 * time.Millisecond
+	}
+
+	ccs, pk, vk, _, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+	hash, err := hash_proof.MiMCHash(ecc.BN254, big.NewInt(testutil.FixturePreImage))
+	if err != nil {
+		t.Fatalf("Failed to compute MiMC hash: %v", err)
+	}
+	witness, err := frontend.NewWitness(&hash_proof.HashCircuit{PreImage: testutil.FixturePreImage, Hash: hash}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("Failed to create witness: %v", err)
+	}
+
+	goroutines := runtime.NumGoroutine()
+	heap := heapInuse()
+	deadline := time.Now().Add(duration)
+	workers := max(4, runtime.GOMAXPROCS(0))
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		errs      []error
+		wg        sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start := time.Now()
+				proof, err := groth16.Prove(ccs, pk, witness)
+				if err == nil {
+					err = groth16.Verify(proof, vk, publicWitness)
+				}
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				latencies = append(latencies, elapsed)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				mu.Unlock()
+
+				if time.Now().After(deadline) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) != 0 {
+		t.Fatalf("%d of %d requests failed, first: %v", len(errs), len(latencies), errs[0])
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p int) time.Duration { return latencies[(len(latencies)-1)*p/100] }
+	t.Logf("%d requests on %d workers in %s: p50 %s, p95 %s, p99 %s",
+		len(latencies), workers, duration, percentile(50), percentile(95), percentile(99))
+	if p99 := percentile(99); p99 > *soakMaxP99 {
+		t.Fatalf("p99 latency %s exceeds %s", p99, *soakMaxP99)
+	}
+
+	// The prover's worker goroutines wind down shortly after it returns.
+	for settle := time.Now().Add(5 * time.Second); runtime.NumGoroutine() > goroutines; {
+		if time.Now().After(settle) {
+			t.Fatalf("Goroutines leaked: %d before, %d after", goroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Memory the prover retains across requests, such as caches that grow
+	// with every proof, shows up as in-use heap that GC cannot reclaim.
+	after := heapInuse()
+	t.Logf("Heap in use: %d bytes before, %d after", heap, after)
+	if after > heap+*soakMaxHeap {
+		t.Fatalf("Heap grew by %d bytes, more than %d", after-heap, *soakMaxHeap)
+	}
+}
@@ -0,0 +1,168 @@
+package hash_proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+// CompileSolidity runs `solc --bin` on the Solidity file at path and returns
+// an error carrying solc's stderr if compilation fails.
+func CompileSolidity(path string) error {
+	solc, err := exec.LookPath("solc")
+	if err != nil {
+		return fmt.Errorf("solc not found: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(solc, "--bin", path)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("solc failed to compile %s: %w\n%s", path, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ErrSolidityCommitments is returned by SimulateSolidityVerify for verifiers
+// of circuits with BSB22 commitments, such as those using rangecheck. Their
+// contracts also check the Pedersen commitment proof and derive extra public
+// inputs from it, which the simulation does not reproduce.
+var ErrSolidityCommitments = errors.New("simulating verifiers with Pedersen commitments is not supported")
+
+var solidityConstant = regexp.MustCompile(`uint256\s+constant\s+(\w+)\s*=\s*(0x[0-9a-fA-F]+|[0-9]+)\s*;`)
+
+// SimulateSolidityVerify runs the pairing check of the gnark-exported BN254
+// verifier at solidityPath in Go, with the verifying key constants parsed
+// from its source, so a verifier exported from another setup than the proof
+// is caught before deployment. proofWords and inputs are the verifyProof
+// arguments, in decimal or 0x-prefixed hexadecimal. It returns false where
+// the contract would revert, and an error if the source or arguments cannot
+// be parsed. Verifiers with commitments are rejected with
+// ErrSolidityCommitments.
+func SimulateSolidityVerify(solidityPath string, proofWords [8]string, inputs []string) (bool, error) {
+	src, err := os.ReadFile(solidityPath)
+	if err != nil {
+		return false, err
+	}
+	constants := map[string]*big.Int{}
+	for _, m := range solidityConstant.FindAllStringSubmatch(string(src), -1) {
+		v, ok := new(big.Int).SetString(m[2], 0)
+		if !ok {
+			return false, fmt.Errorf("%s: malformed constant %s", solidityPath, m[1])
+		}
+		constants[m[1]] = v
+	}
+	if _, ok := constants["PEDERSEN_G_X_0"]; ok {
+		return false, fmt.Errorf("%s: %w", solidityPath, ErrSolidityCommitments)
+	}
+	constant := func(name string) (*big.Int, error) {
+		v, ok := constants[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing constant %s", solidityPath, name)
+		}
+		return v, nil
+	}
+	g1 := func(x, y string) (bn254.G1Affine, error) {
+		var p bn254.G1Affine
+		vx, err := constant(x)
+		if err != nil {
+			return p, err
+		}
+		vy, err := constant(y)
+		if err != nil {
+			return p, err
+		}
+		p.X.SetBigInt(vx)
+		p.Y.SetBigInt(vy)
+		return p, nil
+	}
+	g2 := func(prefix string) (bn254.G2Affine, error) {
+		var p bn254.G2Affine
+		for _, c := range []struct {
+			name string
+			dst  *fp.Element
+		}{
+			{prefix + "_X_0", &p.X.A0}, {prefix + "_X_1", &p.X.A1},
+			{prefix + "_Y_0", &p.Y.A0}, {prefix + "_Y_1", &p.Y.A1},
+		} {
+			v, err := constant(c.name)
+			if err != nil {
+				return p, err
+			}
+			c.dst.SetBigInt(v)
+		}
+		return p, nil
+	}
+
+	alpha, err := g1("ALPHA_X", "ALPHA_Y")
+	if err != nil {
+		return false, err
+	}
+	var negG2 [3]bn254.G2Affine
+	for i, prefix := range []string{"BETA_NEG", "GAMMA_NEG", "DELTA_NEG"} {
+		if negG2[i], err = g2(prefix); err != nil {
+			return false, err
+		}
+	}
+	betaNeg, gammaNeg, deltaNeg := negG2[0], negG2[1], negG2[2]
+
+	if _, ok := constants[fmt.Sprintf("PUB_%d_X", len(inputs))]; ok {
+		return false, fmt.Errorf("%s: verifier expects more than %d public inputs", solidityPath, len(inputs))
+	}
+	l, err := g1("CONSTANT_X", "CONSTANT_Y")
+	if err != nil {
+		return false, err
+	}
+	for i, in := range inputs {
+		s, ok := new(big.Int).SetString(in, 0)
+		if !ok || s.Sign() < 0 {
+			return false, fmt.Errorf("public input %d is not a valid integer: %q", i, in)
+		}
+		pub, err := g1(fmt.Sprintf("PUB_%d_X", i), fmt.Sprintf("PUB_%d_Y", i))
+		if err != nil {
+			return false, err
+		}
+		// The contract reverts with PublicInputNotInField.
+		if s.Cmp(ecc.BN254.ScalarField()) >= 0 {
+			return false, nil
+		}
+		var term bn254.G1Affine
+		term.ScalarMultiplication(&pub, s)
+		l.Add(&l, &term)
+	}
+
+	var words [8]fp.Element
+	for i, w := range proofWords {
+		v, ok := new(big.Int).SetString(w, 0)
+		if !ok || v.Sign() < 0 {
+			return false, fmt.Errorf("proof word %s is not a valid integer: %q", proofLabels[i], w)
+		}
+		// The pairing precompile rejects unreduced coordinates.
+		if v.Cmp(fp.Modulus()) >= 0 {
+			return false, nil
+		}
+		words[i].SetBigInt(v)
+	}
+	a := bn254.G1Affine{X: words[0], Y: words[1]}
+	var b bn254.G2Affine
+	b.X.A1, b.X.A0, b.Y.A1, b.Y.A0 = words[2], words[3], words[4], words[5]
+	c := bn254.G1Affine{X: words[6], Y: words[7]}
+	if !a.IsOnCurve() || !c.IsOnCurve() || !b.IsOnCurve() || !b.IsInSubGroup() {
+		return false, nil
+	}
+
+	// e(A, B) · e(C, -δ) · e(α, -β) · e(L, -γ) == 1
+	return bn254.PairingCheck(
+		[]bn254.G1Affine{a, c, alpha, l},
+		[]bn254.G2Affine{b, deltaNeg, betaNeg, gammaNeg},
+	)
+}
@@ -0,0 +1,104 @@
+package hash_proof_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func writeSolidityVerifier(t *testing.T, vk groth16.VerifyingKey, name string) string {
+	var buf bytes.Buffer
+	if err := vk.ExportSolidity(&buf); err != nil {
+		t.Fatalf("Failed to export Solidity verifier: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write Solidity verifier to file: %v", err)
+	}
+	return path
+}
+
+func TestSimulateSolidityVerify(t *testing.T) {
+	_, _, vk, proof, _ := testutil.GenerateFixture(t, ecc.BN254)
+	path := writeSolidityVerifier(t, vk, "HashProofVerifier.sol")
+
+	raw := proof.(interface{ MarshalSolidity() []byte }).MarshalSolidity()
+	var words [8]string
+	for i := range words {
+		words[i] = new(big.Int).SetBytes(raw[i*32 : (i+1)*32]).String()
+	}
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+
+	ok, err := hash_proof.SimulateSolidityVerify(path, words, []string{hash})
+	if err != nil {
+		t.Fatalf("Failed to simulate verification: %v", err)
+	}
+	if !ok {
+		t.Fatal("Simulated verifier rejected a valid proof")
+	}
+
+	ok, err = hash_proof.SimulateSolidityVerify(path, words, []string{"42"})
+	if err != nil {
+		t.Fatalf("Failed to simulate verification: %v", err)
+	}
+	if ok {
+		t.Fatal("Simulated verifier accepted a wrong public input")
+	}
+
+	if _, err := hash_proof.SimulateSolidityVerify(path, words, nil); err == nil {
+		t.Fatal("Expected an error for a missing public input")
+	}
+
+	// A verifier exported from another setup of the same circuit.
+	otherCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hash_proof.HashCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	_, otherVK, err := groth16.Setup(otherCCS)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+	other := writeSolidityVerifier(t, otherVK, "OtherVerifier.sol")
+
+	ok, err = hash_proof.SimulateSolidityVerify(other, words, []string{hash})
+	if err != nil {
+		t.Fatalf("Failed to simulate verification: %v", err)
+	}
+	if ok {
+		t.Fatal("Simulated verifier accepted a proof from a different setup")
+	}
+}
+
+func TestSimulateSolidityVerifyCommitments(t *testing.T) {
+	// CoprimeHashCircuit range-checks its inputs, which gnark implements
+	// with a BSB22 commitment.
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hash_proof.CoprimeHashCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	_, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+	path := writeSolidityVerifier(t, vk, "CoprimeVerifier.sol")
+
+	var words [8]string
+	for i := range words {
+		words[i] = "0"
+	}
+	_, err = hash_proof.SimulateSolidityVerify(path, words, []string{"0", "0"})
+	if !errors.Is(err, hash_proof.ErrSolidityCommitments) {
+		t.Fatalf("Expected ErrSolidityCommitments, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package hash_proof
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+func TestCompileSolidity(t *testing.T) {
+	if _, err := exec.LookPath("solc"); err != nil {
+		t.Skip("solc not installed")
+	}
+
+	var circuit HashCircuit
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+
+	_, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+
+	var solidityBuf bytes.Buffer
+	err = vk.ExportSolidity(&solidityBuf)
+	if err != nil {
+		t.Fatalf("Failed to export Solidity verifier: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "HashProofVerifier.sol")
+	err = os.WriteFile(path, solidityBuf.Bytes(), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write Solidity verifier to file: %v", err)
+	}
+
+	if err := CompileSolidity(path); err != nil {
+		t.Fatalf("Exported verifier does not compile: %v", err)
+	}
+
+	broken := filepath.Join(dir, "Broken.sol")
+	err = os.WriteFile(broken, []byte("pragma solidity ^0.8.0;\ncontract Broken {"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write broken Solidity file: %v", err)
+	}
+	if err := CompileSolidity(broken); err == nil {
+		t.Fatal("Expected a compilation error for broken Solidity")
+	}
+}
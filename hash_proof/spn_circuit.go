@@ -0,0 +1,93 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// spnRounds is the number of substitution-permutation rounds applied to the
+// two-lane state. Each round costs two x^5 S-boxes, i.e. six constraints.
+const spnRounds = 8
+
+// spnRoundConstants holds one constant per round and lane, derived from
+// SHA-256 and truncated to 248 bits so they fit every supported scalar field.
+var spnRoundConstants = func() [spnRounds][2]*big.Int {
+	var rc [spnRounds][2]*big.Int
+	for i := range rc {
+		for j := range rc[i] {
+			digest := sha256.Sum256([]byte(fmt.Sprintf("hash_proof/spn/%d/%d", i, j)))
+			rc[i][j] = new(big.Int).SetBytes(digest[:31])
+		}
+	}
+	return rc
+}()
+
+// SPNCommitCircuit proves that Commit is the AES-like permutation of the
+// secret under the public Key, and that Hash is the MiMC digest of the secret.
+type SPNCommitCircuit struct {
+	PreImage frontend.Variable `gnark:",secret"`
+	Key      frontend.Variable `gnark:",public"`
+	Commit   frontend.Variable `gnark:",public"`
+	Hash     frontend.Variable `gnark:",public"`
+}
+
+func (circuit *SPNCommitCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	api.AssertIsEqual(circuit.Commit, permuteAESlike(api, circuit.PreImage, circuit.Key))
+
+	return nil
+}
+
+// permuteAESlike is the in-circuit counterpart of PermuteAESlike. The state is
+// (secret, key); every round adds the key and a round constant to each lane,
+// applies the x^5 S-box and mixes the lanes with the matrix [[2, 1], [1, 2]].
+func permuteAESlike(api frontend.API, secret, key frontend.Variable) frontend.Variable {
+	state := [2]frontend.Variable{secret, key}
+	for i := 0; i < spnRounds; i++ {
+		for j := range state {
+			x := api.Add(state[j], key, spnRoundConstants[i][j])
+			x2 := api.Mul(x, x)
+			state[j] = api.Mul(x2, x2, x)
+		}
+		a, b := state[0], state[1]
+		state[0] = api.Add(api.Mul(a, 2), b)
+		state[1] = api.Add(a, api.Mul(b, 2))
+	}
+	return state[0]
+}
+
+// PermuteAESlike computes out of circuit the commitment checked by
+// SPNCommitCircuit.
+func PermuteAESlike(curveID ecc.ID, secret, key *big.Int) *big.Int {
+	modulus := curveID.ScalarField()
+	state := [2]*big.Int{
+		new(big.Int).Mod(secret, modulus),
+		new(big.Int).Mod(key, modulus),
+	}
+	five := big.NewInt(5)
+	for i := 0; i < spnRounds; i++ {
+		for j := range state {
+			x := new(big.Int).Add(state[j], key)
+			x.Add(x, spnRoundConstants[i][j])
+			state[j] = x.Exp(x, five, modulus)
+		}
+		a := new(big.Int).Lsh(state[0], 1)
+		a.Add(a, state[1]).Mod(a, modulus)
+		b := new(big.Int).Lsh(state[1], 1)
+		b.Add(b, state[0]).Mod(b, modulus)
+		state[0], state[1] = a, b
+	}
+	return state[0]
+}
@@ -0,0 +1,56 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+func TestSPNCommitCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit SPNCommitCircuit
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	t.Logf("SPNCommitCircuit constraints: %d", ccs.GetNbConstraints())
+
+	preImage := big.NewInt(35)
+	key := big.NewInt(0xC0FFEE)
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	commit := PermuteAESlike(ecc.BN254, preImage, key)
+
+	assert.ProverSucceeded(&circuit, &SPNCommitCircuit{
+		PreImage: preImage,
+		Key:      key,
+		Commit:   commit,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+
+	assert.ProverFailed(&circuit, &SPNCommitCircuit{
+		PreImage: preImage,
+		Key:      big.NewInt(0xBEEF),
+		Commit:   commit,
+		Hash:     hash,
+	}, test.WithCurves(ecc.BN254))
+}
+
+func TestPermuteAESlike(t *testing.T) {
+	key := big.NewInt(7)
+
+	a := PermuteAESlike(ecc.BN254, big.NewInt(1), key)
+	b := PermuteAESlike(ecc.BN254, big.NewInt(2), key)
+	if a.Cmp(b) == 0 {
+		t.Fatal("Distinct secrets produced the same commitment")
+	}
+
+	if PermuteAESlike(ecc.BN254, big.NewInt(1), key).Cmp(a) != 0 {
+		t.Fatal("Permutation is not deterministic")
+	}
+}
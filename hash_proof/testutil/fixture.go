@@ -0,0 +1,132 @@
+// Package testutil provides shared fixtures for tests of hash_proof and its
+// commands.
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"hash_proof/hash_proof"
+)
+
+// FixturePreImage is the secret the fixture proof is made for.
+const FixturePreImage = 35
+
+// fixture holds the serialized artifacts of one curve, so every caller gets
+// fresh objects and none can alter what the next one sees.
+type fixture struct {
+	once          sync.Once
+	err           error
+	ccs           []byte
+	pk            []byte
+	vk            []byte
+	proof         []byte
+	publicWitness []byte
+}
+
+var (
+	fixturesMu sync.Mutex
+	fixtures   = map[ecc.ID]*fixture{}
+)
+
+// GenerateFixture returns a compiled HashCircuit, its Groth16 keys and a proof
+// of FixturePreImage with its public witness. Setup and proving run once per
+// curve and test binary; each call returns its own copies of the artifacts.
+func GenerateFixture(t testing.TB, curveID ecc.ID) (constraint.ConstraintSystem, groth16.ProvingKey, groth16.VerifyingKey, groth16.Proof, witness.Witness) {
+	t.Helper()
+
+	fixturesMu.Lock()
+	f, ok := fixtures[curveID]
+	if !ok {
+		f = &fixture{}
+		fixtures[curveID] = f
+	}
+	fixturesMu.Unlock()
+
+	f.once.Do(func() { f.err = f.generate(curveID) })
+	if f.err != nil {
+		t.Fatalf("Failed to generate fixture for %s: %v", curveID, f.err)
+	}
+
+	ccs := groth16.NewCS(curveID)
+	pk := groth16.NewProvingKey(curveID)
+	vk := groth16.NewVerifyingKey(curveID)
+	proof := groth16.NewProof(curveID)
+	for _, a := range []struct {
+		data []byte
+		dst  interface {
+			ReadFrom(r io.Reader) (int64, error)
+		}
+	}{{f.ccs, ccs}, {f.pk, pk}, {f.vk, vk}, {f.proof, proof}} {
+		if _, err := a.dst.ReadFrom(bytes.NewReader(a.data)); err != nil {
+			t.Fatalf("Failed to read fixture: %v", err)
+		}
+	}
+
+	publicWitness, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+	if err := publicWitness.UnmarshalBinary(f.publicWitness); err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	return ccs, pk, vk, proof, publicWitness
+}
+
+func (f *fixture) generate(curveID ecc.ID) error {
+	var circuit hash_proof.HashCircuit
+	ccs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		return err
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hash_proof.MiMCHash(curveID, big.NewInt(FixturePreImage))
+	if err != nil {
+		return err
+	}
+	w, err := frontend.NewWitness(&hash_proof.HashCircuit{PreImage: FixturePreImage, Hash: hash}, curveID.ScalarField())
+	if err != nil {
+		return err
+	}
+	publicWitness, err := w.Public()
+	if err != nil {
+		return err
+	}
+
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, a := range []struct {
+		src interface {
+			WriteTo(w io.Writer) (int64, error)
+		}
+		dst *[]byte
+	}{{ccs, &f.ccs}, {pk, &f.pk}, {vk, &f.vk}, {proof, &f.proof}} {
+		buf.Reset()
+		if _, err := a.src.WriteTo(&buf); err != nil {
+			return err
+		}
+		*a.dst = bytes.Clone(buf.Bytes())
+	}
+	f.publicWitness, err = publicWitness.MarshalBinary()
+	return err
+}
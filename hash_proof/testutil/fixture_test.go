@@ -0,0 +1,30 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func TestGenerateFixture(t *testing.T) {
+	_, _, vk, proof, publicWitness := GenerateFixture(t, ecc.BN254)
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		t.Fatalf("Failed to verify fixture proof: %v", err)
+	}
+
+	// Tampering with one caller's copy must not reach the next caller.
+	publicWitness.Vector().(fr.Vector)[0].SetUint64(1)
+	if err := groth16.Verify(proof, vk, publicWitness); err == nil {
+		t.Fatal("Expected the tampered witness to be rejected")
+	}
+
+	ccs, pk, vk, proof, publicWitness := GenerateFixture(t, ecc.BN254)
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		t.Fatalf("Fixture was altered by an earlier caller: %v", err)
+	}
+	if ccs.GetNbPublicVariables() != 2 || pk.CurveID() != ecc.BN254 {
+		t.Fatalf("Unexpected fixture: %d public variables on %s", ccs.GetNbPublicVariables(), pk.CurveID())
+	}
+}
@@ -0,0 +1,33 @@
+package hash_proof
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// CommittedThresholdCircuit proves that the preimage of Hash is at least the
+// preimage of ThresholdHash, without revealing either value.
+type CommittedThresholdCircuit struct {
+	PreImage      frontend.Variable `gnark:",secret"`
+	Threshold     frontend.Variable `gnark:",secret"`
+	Hash          frontend.Variable `gnark:",public"`
+	ThresholdHash frontend.Variable `gnark:",public"`
+}
+
+func (circuit *CommittedThresholdCircuit) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	hFunc.Reset()
+	hFunc.Write(circuit.Threshold)
+	api.AssertIsEqual(circuit.ThresholdHash, hFunc.Sum())
+
+	api.AssertIsLessOrEqual(circuit.Threshold, circuit.PreImage)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestCommittedThresholdCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var circuit CommittedThresholdCircuit
+
+	assignment := func(secret, threshold int64) *CommittedThresholdCircuit {
+		hash, err := MiMCHash(ecc.BN254, big.NewInt(secret))
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		thresholdHash, err := MiMCHash(ecc.BN254, big.NewInt(threshold))
+		if err != nil {
+			t.Fatalf("Failed to compute MiMC hash: %v", err)
+		}
+		return &CommittedThresholdCircuit{
+			PreImage:      secret,
+			Threshold:     threshold,
+			Hash:          hash,
+			ThresholdHash: thresholdHash,
+		}
+	}
+
+	assert.ProverSucceeded(&circuit, assignment(35, 18), test.WithCurves(ecc.BN254))
+	assert.ProverSucceeded(&circuit, assignment(35, 35), test.WithCurves(ecc.BN254))
+	assert.ProverFailed(&circuit, assignment(17, 18), test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,73 @@
+package hash_proof
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// ProofLogEntry is one line of the proof transcript. It identifies a proof and
+// what it was checked against, and deliberately has no room for the secret.
+type ProofLogEntry struct {
+	Timestamp        time.Time `json:"timestamp"`
+	HashSuite        HashSuite `json:"hashSuite"`
+	CircuitHash      string    `json:"circuitHash"`
+	VKFingerprint    string    `json:"vkFingerprint"`
+	PublicInputs     []string  `json:"publicInputs"`
+	ProofFingerprint string    `json:"proofFingerprint"`
+}
+
+// Fingerprint returns the hex SHA-256 of the serialization of v, e.g. a
+// constraint system, verifying key or proof.
+func Fingerprint(v io.WriterTo) (string, error) {
+	h := sha256.New()
+	if _, err := v.WriteTo(h); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NewProofLogEntry describes proof for the transcript. It only accepts the
+// public witness so the secret can never end up in the log.
+func NewProofLogEntry(ccs constraint.ConstraintSystem, vk groth16.VerifyingKey, proof groth16.Proof, publicWitness witness.Witness) (ProofLogEntry, error) {
+	var entry ProofLogEntry
+	var err error
+
+	entry.Timestamp = time.Now().UTC()
+	entry.HashSuite = CurrentHashSuite
+	if entry.CircuitHash, err = Fingerprint(ccs); err != nil {
+		return ProofLogEntry{}, err
+	}
+	if entry.VKFingerprint, err = Fingerprint(vk); err != nil {
+		return ProofLogEntry{}, err
+	}
+	if entry.ProofFingerprint, err = Fingerprint(proof); err != nil {
+		return ProofLogEntry{}, err
+	}
+
+	inputs, err := publicInputs(publicWitness)
+	if err != nil {
+		return ProofLogEntry{}, err
+	}
+	for _, in := range inputs {
+		entry.PublicInputs = append(entry.PublicInputs, in.String())
+	}
+
+	return entry, nil
+}
+
+// LogProof appends entry to w as a single JSON line.
+func LogProof(w io.Writer, entry ProofLogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,64 @@
+package hash_proof_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestLogProof(t *testing.T) {
+	ccs, _, vk, proof, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+
+	entry, err := hash_proof.NewProofLogEntry(ccs, vk, proof, publicWitness)
+	if err != nil {
+		t.Fatalf("Failed to create log entry: %v", err)
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if err := hash_proof.LogProof(&buf, entry); err != nil {
+			t.Fatalf("Failed to log proof: %v", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+
+		var fields map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", lines, err)
+		}
+
+		for _, key := range []string{"timestamp", "hashSuite", "circuitHash", "vkFingerprint", "publicInputs", "proofFingerprint"} {
+			if _, ok := fields[key]; !ok {
+				t.Fatalf("Line %d is missing %q", lines, key)
+			}
+		}
+		for key := range fields {
+			if strings.Contains(strings.ToLower(key), "preimage") {
+				t.Fatalf("Line %d contains a preimage field %q", lines, key)
+			}
+		}
+		if strings.Contains(scanner.Text(), `"35"`) {
+			t.Fatalf("Line %d leaks the preimage: %s", lines, scanner.Text())
+		}
+
+		inputs := fields["publicInputs"].([]any)
+		if len(inputs) != 1 || inputs[0] != hash {
+			t.Fatalf("Unexpected public inputs: %v", inputs)
+		}
+	}
+	if lines != 2 {
+		t.Fatalf("Expected 2 log lines, got %d", lines)
+	}
+}
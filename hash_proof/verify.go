@@ -0,0 +1,146 @@
+package hash_proof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// Verification steps a VerifyResult can blame.
+const (
+	VerifyStepCurve        = "curve"
+	VerifyStepPublicInputs = "public inputs"
+	VerifyStepProof        = "proof"
+	VerifyStepPairing      = "pairing"
+)
+
+// VerifyResult is the outcome of VerifyDetailed. When Valid is false, Step
+// names the first check that failed and Reason explains it.
+type VerifyResult struct {
+	Valid    bool
+	Step     string
+	Reason   string
+	Duration time.Duration
+}
+
+// VerifyDetailed verifies proof like groth16.Verify, but first runs the
+// checks that tell failures apart. A curve or public input count mismatch
+// points at the wrong verifying key, an undecodable proof at corruption in
+// transit, and a failed pairing at a proof that does not match these public
+// inputs under this key. The pairing equation fails as a whole, so it cannot
+// say which input is wrong.
+func VerifyDetailed(proof groth16.Proof, vk groth16.VerifyingKey, pub witness.Witness) VerifyResult {
+	start := time.Now()
+	fail := func(step, format string, args ...any) VerifyResult {
+		return VerifyResult{Step: step, Reason: fmt.Sprintf(format, args...), Duration: time.Since(start)}
+	}
+
+	if proof.CurveID() != vk.CurveID() {
+		return fail(VerifyStepCurve, "proof is over %s, verifying key over %s", proof.CurveID(), vk.CurveID())
+	}
+
+	inputs, err := publicInputs(pub)
+	if err != nil {
+		return fail(VerifyStepPublicInputs, "cannot decode public witness: %v", err)
+	}
+	if len(inputs) != vk.NbPublicWitness() {
+		return fail(VerifyStepPublicInputs, "verifying key expects %d public inputs, witness has %d; the key may be for another circuit", vk.NbPublicWitness(), len(inputs))
+	}
+
+	// Decoding checks that every point is on its curve and in the right
+	// subgroup.
+	var buf bytes.Buffer
+	if _, err := proof.WriteRawTo(&buf); err != nil {
+		return fail(VerifyStepProof, "cannot encode proof: %v", err)
+	}
+	if _, err := groth16.NewProof(proof.CurveID()).ReadFrom(&buf); err != nil {
+		return fail(VerifyStepProof, "proof is malformed: %v", err)
+	}
+
+	if err := groth16.Verify(proof, vk, pub); err != nil {
+		return fail(VerifyStepPairing, "pairing check failed: the proof does not match these public inputs under this key (%v)", err)
+	}
+
+	return VerifyResult{Valid: true, Duration: time.Since(start)}
+}
+
+// VerifyHex verifies a hex-encoded proof against a hex-encoded verifying key,
+// both as written by WriteTo or WriteRawTo, with an optional 0x prefix.
+// inputJSON is a JSON array of the public inputs in verifier order, each a
+// decimal or 0x-prefixed hexadecimal string. The curve is taken from the
+// verifying key. It backs the WASM verifier in cmd/wasm, so it only depends
+// on its string arguments.
+func VerifyHex(proofHex, vkHex, inputJSON string) (bool, error) {
+	vkData, err := hex.DecodeString(strings.TrimPrefix(vkHex, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("verifying key is not valid hex: %w", err)
+	}
+	vk, err := readVerifyingKey(vkData)
+	if err != nil {
+		return false, err
+	}
+	curveID := vk.CurveID()
+
+	proofData, err := hex.DecodeString(strings.TrimPrefix(proofHex, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("proof is not valid hex: %w", err)
+	}
+	proof := groth16.NewProof(curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofData)); err != nil {
+		return false, fmt.Errorf("proof is not a %s proof: %w", curveID, err)
+	}
+
+	var raw []string
+	if err := json.Unmarshal([]byte(inputJSON), &raw); err != nil {
+		return false, fmt.Errorf("public inputs must be a JSON array of strings: %w", err)
+	}
+	if len(raw) != vk.NbPublicWitness() {
+		return false, fmt.Errorf("verifying key expects %d public inputs, got %d", vk.NbPublicWitness(), len(raw))
+	}
+	pub, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return false, err
+	}
+	values := make(chan any, len(raw))
+	for i, r := range raw {
+		v, ok := new(big.Int).SetString(r, 0)
+		if !ok || v.Sign() < 0 || v.Cmp(curveID.ScalarField()) >= 0 {
+			return false, fmt.Errorf("public input %d is not a %s scalar field element: %q", i, curveID, r)
+		}
+		values <- v
+	}
+	close(values)
+	if err := pub.Fill(len(raw), 0, values); err != nil {
+		return false, err
+	}
+
+	return groth16.Verify(proof, vk, pub) == nil, nil
+}
+
+// readVerifyingKey decodes data as a verifying key over the first supported
+// curve whose encoding consumes it exactly.
+func readVerifyingKey(data []byte) (groth16.VerifyingKey, error) {
+	var curves []ecc.ID
+	for curveID := range mimcByCurve {
+		curves = append(curves, curveID)
+	}
+	slices.Sort(curves)
+
+	for _, curveID := range curves {
+		vk := groth16.NewVerifyingKey(curveID)
+		n, err := vk.ReadFrom(bytes.NewReader(data))
+		if err == nil && n == int64(len(data)) {
+			return vk, nil
+		}
+	}
+	return nil, fmt.Errorf("verifying key is not a Groth16 key over a supported curve")
+}
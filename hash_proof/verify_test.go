@@ -0,0 +1,104 @@
+package hash_proof_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+
+	"hash_proof/hash_proof"
+	"hash_proof/hash_proof/testutil"
+)
+
+func TestVerifyDetailed(t *testing.T) {
+	_, _, vk, proof, publicWitness := testutil.GenerateFixture(t, ecc.BN254)
+
+	result := hash_proof.VerifyDetailed(proof, vk, publicWitness)
+	if !result.Valid || result.Step != "" || result.Duration <= 0 {
+		t.Fatalf("Unexpected result for a valid proof: %+v", result)
+	}
+
+	_, _, _, _, tampered := testutil.GenerateFixture(t, ecc.BN254)
+	tampered.Vector().(fr.Vector)[0].SetUint64(42)
+	result = hash_proof.VerifyDetailed(proof, vk, tampered)
+	if result.Valid || result.Step != hash_proof.VerifyStepPairing || !strings.Contains(result.Reason, "pairing check failed") {
+		t.Fatalf("Unexpected result for a tampered input: %+v", result)
+	}
+
+	bridge, err := frontend.NewWitness(&hash_proof.BridgeCircuit{MiMCCommitment: 1, PoseidonCommitment: 2}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+	result = hash_proof.VerifyDetailed(proof, vk, bridge)
+	if result.Valid || result.Step != hash_proof.VerifyStepPublicInputs || !strings.Contains(result.Reason, "expects 1 public inputs, witness has 2") {
+		t.Fatalf("Unexpected result for another circuit's witness: %+v", result)
+	}
+
+	_, _, _, corrupted, _ := testutil.GenerateFixture(t, ecc.BN254)
+	corrupted.(*groth16bn254.Proof).Ar.X.SetOne()
+	result = hash_proof.VerifyDetailed(corrupted, vk, publicWitness)
+	if result.Valid || result.Step != hash_proof.VerifyStepProof {
+		t.Fatalf("Unexpected result for an off-curve proof point: %+v", result)
+	}
+
+	result = hash_proof.VerifyDetailed(proof, groth16.NewVerifyingKey(ecc.BLS12_381), publicWitness)
+	if result.Valid || result.Step != hash_proof.VerifyStepCurve {
+		t.Fatalf("Unexpected result for a key on another curve: %+v", result)
+	}
+}
+
+// hexArtifacts returns the fixture proof and verifying key for curveID, hex
+// encoded as VerifyHex expects them.
+func hexArtifacts(t *testing.T, curveID ecc.ID) (proofHex, vkHex string) {
+	_, _, vk, proof, _ := testutil.GenerateFixture(t, curveID)
+	var vkBuf, proofBuf bytes.Buffer
+	if _, err := vk.WriteTo(&vkBuf); err != nil {
+		t.Fatalf("Failed to write verifying key: %v", err)
+	}
+	if _, err := proof.WriteTo(&proofBuf); err != nil {
+		t.Fatalf("Failed to write proof: %v", err)
+	}
+	return hex.EncodeToString(proofBuf.Bytes()), hex.EncodeToString(vkBuf.Bytes())
+}
+
+func TestVerifyHex(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		t.Run(curveID.String(), func(t *testing.T) {
+			proofHex, vkHex := hexArtifacts(t, curveID)
+			hash, err := hash_proof.MiMCHash(curveID, big.NewInt(testutil.FixturePreImage))
+			if err != nil {
+				t.Fatalf("Failed to compute MiMC hash: %v", err)
+			}
+
+			ok, err := hash_proof.VerifyHex("0x"+proofHex, vkHex, `["`+hash.String()+`"]`)
+			if err != nil || !ok {
+				t.Fatalf("Valid proof rejected: %v, %v", ok, err)
+			}
+			ok, err = hash_proof.VerifyHex(proofHex, vkHex, `["42"]`)
+			if err != nil || ok {
+				t.Fatalf("Proof accepted for a wrong input: %v, %v", ok, err)
+			}
+		})
+	}
+
+	proofHex, vkHex := hexArtifacts(t, ecc.BN254)
+	for name, args := range map[string][3]string{
+		"bad proof hex":      {"zz", vkHex, `["1"]`},
+		"bad key":            {proofHex, "00", `["1"]`},
+		"truncated proof":    {proofHex[:64], vkHex, `["1"]`},
+		"input not an array": {proofHex, vkHex, `{"Hash": "1"}`},
+		"too many inputs":    {proofHex, vkHex, `["1", "2"]`},
+		"input out of field": {proofHex, vkHex, `["` + ecc.BN254.ScalarField().String() + `"]`},
+	} {
+		if _, err := hash_proof.VerifyHex(args[0], args[1], args[2]); err == nil {
+			t.Fatalf("Expected an error for %s", name)
+		}
+	}
+}
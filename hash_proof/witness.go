@@ -0,0 +1,181 @@
+package hash_proof
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fr_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/schema"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	EnvPreImage = "ZK_PREIMAGE"
+	EnvHash     = "ZK_HASH"
+)
+
+// WitnessFromEnv builds a full HashCircuit witness from the ZK_PREIMAGE and
+// ZK_HASH environment variables, so secrets never have to appear in flags or
+// files. Values may be decimal or 0x-prefixed hexadecimal.
+func WitnessFromEnv(curveID ecc.ID) (witness.Witness, error) {
+	preImage, err := fieldFromEnv(EnvPreImage, curveID)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := fieldFromEnv(EnvHash, curveID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := &HashCircuit{
+		PreImage: preImage,
+		Hash:     hash,
+	}
+	return frontend.NewWitness(assignment, curveID.ScalarField())
+}
+
+func fieldFromEnv(name string, curveID ecc.ID) (*big.Int, error) {
+	raw, ok := os.LookupEnv(name)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	v, ok := new(big.Int).SetString(raw, 0)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not a valid integer", name)
+	}
+	if v.Sign() < 0 || v.Cmp(curveID.ScalarField()) >= 0 {
+		return nil, fmt.Errorf("environment variable %s is out of range for %s", name, curveID)
+	}
+	return v, nil
+}
+
+// publicInputs decodes the public elements of a witness, in declaration
+// order. A circuit without public inputs yields an empty slice.
+func publicInputs(w witness.Witness) ([]*big.Int, error) {
+	pub, err := w.Public()
+	if err != nil {
+		return nil, err
+	}
+	return vectorElements(pub)
+}
+
+// witnessElements decodes all elements of a witness, public ones first, and
+// returns how many of them are public.
+func witnessElements(w witness.Witness) ([]*big.Int, int, error) {
+	elems, err := vectorElements(w)
+	if err != nil {
+		return nil, 0, err
+	}
+	public, err := publicInputs(w)
+	if err != nil {
+		return nil, 0, err
+	}
+	return elems, len(public), nil
+}
+
+// vectorElements converts the fr.Vector underlying w to integers.
+func vectorElements(w witness.Witness) ([]*big.Int, error) {
+	switch v := w.Vector().(type) {
+	case fr_bn254.Vector:
+		return frElements(v), nil
+	case fr_bls12377.Vector:
+		return frElements(v), nil
+	case fr_bls12381.Vector:
+		return frElements(v), nil
+	case fr_bls24315.Vector:
+		return frElements(v), nil
+	case fr_bls24317.Vector:
+		return frElements(v), nil
+	case fr_bw6761.Vector:
+		return frElements(v), nil
+	case fr_bw6633.Vector:
+		return frElements(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported witness vector %T", v)
+	}
+}
+
+func frElements[E any, P interface {
+	*E
+	BigInt(*big.Int) *big.Int
+}](v []E) []*big.Int {
+	out := make([]*big.Int, len(v))
+	for i := range v {
+		out[i] = P(&v[i]).BigInt(new(big.Int))
+	}
+	return out
+}
+
+// PublicInputsCommitment returns keccak256 of the public inputs of pub, each
+// encoded as a 32-byte big-endian word. This is abi.encodePacked over the
+// inputs as uint256, for contracts that store only the commitment.
+func PublicInputsCommitment(pub witness.Witness, curveID ecc.ID) (*big.Int, error) {
+	if curveID.ScalarField().BitLen() > 256 {
+		return nil, fmt.Errorf("%s scalar field elements do not fit in a uint256", curveID)
+	}
+
+	inputs, err := publicInputs(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	word := make([]byte, 32)
+	for _, in := range inputs {
+		if in.Cmp(curveID.ScalarField()) >= 0 {
+			return nil, fmt.Errorf("public input %s is not in the %s scalar field", in, curveID)
+		}
+		h.Write(in.FillBytes(word))
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// DescribePublicWitness labels the public inputs of pub with the names of the
+// corresponding circuit fields, e.g. {"Hash": "247..."} for HashCircuit.
+// Elements of arrays are named after their index, as in Filter_3.
+func DescribePublicWitness(circuit frontend.Circuit, pub witness.Witness, curveID ecc.ID) (map[string]string, error) {
+	names, err := publicInputNames(circuit, curveID)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := publicInputs(pub)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) != len(names) {
+		return nil, fmt.Errorf("witness has %d public inputs, circuit declares %d", len(inputs), len(names))
+	}
+
+	described := make(map[string]string, len(names))
+	for i, name := range names {
+		described[name] = inputs[i].String()
+	}
+	return described, nil
+}
+
+// publicInputNames returns the full names of the public inputs of circuit,
+// in the order the verifier expects them.
+func publicInputNames(circuit frontend.Circuit, curveID ecc.ID) ([]string, error) {
+	var names []string
+	tVariable := reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+	_, err := schema.Walk(curveID.ScalarField(), circuit, tVariable, func(leaf schema.LeafInfo, _ reflect.Value) error {
+		if leaf.Visibility == schema.Public {
+			names = append(names, leaf.FullName())
+		}
+		return nil
+	})
+	return names, err
+}
@@ -0,0 +1,227 @@
+package hash_proof
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestWitnessFromEnv(t *testing.T) {
+	t.Setenv(EnvPreImage, "35")
+	t.Setenv(EnvHash, "2474112249751028531650252582366798049474486386634137916759752348728204118534")
+
+	witness, err := WitnessFromEnv(ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to create witness from env: %v", err)
+	}
+
+	var circuit HashCircuit
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+
+	proof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		t.Fatalf("Failed to create proof: %v", err)
+	}
+
+	publicWitness, err := witness.Public()
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	err = groth16.Verify(proof, vk, publicWitness)
+	if err != nil {
+		t.Fatalf("Failed to verify proof: %v", err)
+	}
+}
+
+func TestWitnessFromEnvErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		preImage string
+		hash     string
+		want     string
+	}{
+		{name: "unset preimage", preImage: "", hash: "1", want: EnvPreImage + " is not set"},
+		{name: "unset hash", preImage: "35", hash: "", want: EnvHash + " is not set"},
+		{name: "malformed preimage", preImage: "thirty-five", hash: "1", want: EnvPreImage + " is not a valid integer"},
+		{name: "negative hash", preImage: "35", hash: "-1", want: EnvHash + " is out of range"},
+		{name: "hash above modulus", preImage: "35", hash: ecc.BN254.ScalarField().String(), want: EnvHash + " is out of range"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvPreImage, tc.preImage)
+			t.Setenv(EnvHash, tc.hash)
+
+			_, err := WitnessFromEnv(ecc.BN254)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Unexpected error: got %q, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestPublicInputsCommitment(t *testing.T) {
+	hash, _ := new(big.Int).SetString("2474112249751028531650252582366798049474486386634137916759752348728204118534", 10)
+
+	commit := func(hash *big.Int) *big.Int {
+		pub, err := frontend.NewWitness(&HashCircuit{Hash: hash}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+		if err != nil {
+			t.Fatalf("Failed to create public witness: %v", err)
+		}
+		c, err := PublicInputsCommitment(pub, ecc.BN254)
+		if err != nil {
+			t.Fatalf("Failed to compute commitment: %v", err)
+		}
+		return c
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write(hash.FillBytes(make([]byte, 32)))
+	expected := new(big.Int).SetBytes(h.Sum(nil))
+
+	if c := commit(hash); c.Cmp(expected) != 0 {
+		t.Fatalf("Commitment %s does not match keccak256 of the hash %s", c, expected)
+	}
+	if commit(hash).Cmp(commit(hash)) != 0 {
+		t.Fatal("Commitment is not deterministic")
+	}
+	if commit(big.NewInt(1)).Cmp(expected) == 0 {
+		t.Fatal("Distinct public inputs produced the same commitment")
+	}
+
+	pub, err := frontend.NewWitness(&HashCircuit{Hash: hash}, ecc.BW6_761.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+	if _, err := PublicInputsCommitment(pub, ecc.BW6_761); err == nil {
+		t.Fatal("Expected a scalar field wider than uint256 to be rejected")
+	}
+}
+
+func TestDescribePublicWitness(t *testing.T) {
+	hash := "2474112249751028531650252582366798049474486386634137916759752348728204118534"
+	pub, err := frontend.NewWitness(&HashCircuit{Hash: hash}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	described, err := DescribePublicWitness(&HashCircuit{}, pub, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to describe public witness: %v", err)
+	}
+	if !reflect.DeepEqual(described, map[string]string{"Hash": hash}) {
+		t.Fatalf("Unexpected description: %v", described)
+	}
+
+	median := &MedianHashCircuit{MedianIndex: 1}
+	for i := range median.Hashes {
+		median.Hashes[i] = 10 + i
+	}
+	pub, err = frontend.NewWitness(median, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	described, err = DescribePublicWitness(&MedianHashCircuit{}, pub, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to describe public witness: %v", err)
+	}
+	expected := map[string]string{"Hashes_0": "10", "Hashes_1": "11", "Hashes_2": "12", "MedianIndex": "1"}
+	if !reflect.DeepEqual(described, expected) {
+		t.Fatalf("Unexpected description: got %v, want %v", described, expected)
+	}
+
+	if _, err := DescribePublicWitness(&RotationCircuit{}, pub, ecc.BN254); err == nil {
+		t.Fatal("Expected a witness of another circuit to be rejected")
+	}
+}
+
+type secretOnlyCircuit struct {
+	X frontend.Variable `gnark:",secret"`
+}
+
+func (circuit *secretOnlyCircuit) Define(api frontend.API) error {
+	api.AssertIsDifferent(circuit.X, 0)
+	return nil
+}
+
+func TestWitnessWithoutPublicInputs(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &secretOnlyCircuit{})
+	if err != nil {
+		t.Fatalf("Failed to compile circuit: %v", err)
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		t.Fatalf("Failed to setup: %v", err)
+	}
+
+	full, err := frontend.NewWitness(&secretOnlyCircuit{X: 7}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatalf("Failed to create witness: %v", err)
+	}
+	elems, nbPublic, err := witnessElements(full)
+	if err != nil {
+		t.Fatalf("Failed to decode witness: %v", err)
+	}
+	if nbPublic != 0 || len(elems) != 1 || elems[0].Int64() != 7 {
+		t.Fatalf("Unexpected witness elements %v with %d public", elems, nbPublic)
+	}
+
+	pub, err := full.Public()
+	if err != nil {
+		t.Fatalf("Failed to get public witness: %v", err)
+	}
+	described, err := DescribePublicWitness(&secretOnlyCircuit{}, pub, ecc.BN254)
+	if err != nil {
+		t.Fatalf("Failed to describe public witness: %v", err)
+	}
+	if len(described) != 0 {
+		t.Fatalf("Unexpected description: %v", described)
+	}
+
+	proof, err := groth16.Prove(ccs, pk, full)
+	if err != nil {
+		t.Fatalf("Failed to prove: %v", err)
+	}
+	if result := VerifyDetailed(proof, vk, pub); !result.Valid {
+		t.Fatalf("Expected a valid proof, failed at %s: %s", result.Step, result.Reason)
+	}
+}
+
+func TestPublicInputsOtherCurves(t *testing.T) {
+	hash, err := MiMCHash(ecc.BLS12_381, big.NewInt(35))
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	pub, err := frontend.NewWitness(&HashCircuit{Hash: hash}, ecc.BLS12_381.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		t.Fatalf("Failed to create public witness: %v", err)
+	}
+
+	inputs, err := publicInputs(pub)
+	if err != nil {
+		t.Fatalf("Failed to decode public inputs: %v", err)
+	}
+	if len(inputs) != 1 || inputs[0].Cmp(hash) != 0 {
+		t.Fatalf("Unexpected public inputs %v, want [%s]", inputs, hash)
+	}
+}
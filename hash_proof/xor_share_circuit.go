@@ -0,0 +1,66 @@
+package hash_proof
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// XORShareBits is the width of the secret and of each share of
+// XORShareCircuit.
+const XORShareBits = 64
+
+// XORShareCircuit proves that the preimage of Hash is the bitwise XOR of the
+// public shares. The number of shares is fixed by NewXORShareCircuit. Anyone
+// holding all the shares can reconstruct the secret; the proof binds that
+// reconstruction to the commitment.
+type XORShareCircuit struct {
+	PreImage frontend.Variable   `gnark:",secret"`
+	Hash     frontend.Variable   `gnark:",public"`
+	Shares   []frontend.Variable `gnark:",public"`
+}
+
+// NewXORShareCircuit returns a circuit definition for k shares.
+func NewXORShareCircuit(k int) *XORShareCircuit {
+	return &XORShareCircuit{Shares: make([]frontend.Variable, k)}
+}
+
+func (circuit *XORShareCircuit) Define(api frontend.API) error {
+	if len(circuit.Shares) == 0 {
+		return errors.New("XOR share circuit must be created with NewXORShareCircuit")
+	}
+
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	hFunc.Write(circuit.PreImage)
+	api.AssertIsEqual(circuit.Hash, hFunc.Sum())
+
+	// ToBinary constrains the secret and every share to XORShareBits bits.
+	acc := api.ToBinary(circuit.Shares[0], XORShareBits)
+	for _, share := range circuit.Shares[1:] {
+		bits := api.ToBinary(share, XORShareBits)
+		for i := range acc {
+			acc[i] = api.Xor(acc[i], bits[i])
+		}
+	}
+	secret := api.ToBinary(circuit.PreImage, XORShareBits)
+	for i := range acc {
+		api.AssertIsEqual(secret[i], acc[i])
+	}
+
+	return nil
+}
+
+// XORShares returns the XOR of shares, the secret they reconstruct.
+func XORShares(shares ...*big.Int) *big.Int {
+	secret := new(big.Int)
+	for _, share := range shares {
+		secret.Xor(secret, share)
+	}
+	return secret
+}
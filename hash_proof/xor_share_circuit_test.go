@@ -0,0 +1,41 @@
+package hash_proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestXORShareCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const k = 3
+	shares := []*big.Int{
+		new(big.Int).SetUint64(0xDEADBEEF00C0FFEE),
+		new(big.Int).SetUint64(0x0123456789ABCDEF),
+		new(big.Int).SetUint64(0xFFFF0000FFFF0000),
+	}
+	secret := XORShares(shares...)
+
+	assignment := func(preImage *big.Int) *XORShareCircuit {
+		hash, err := MiMCHash(ecc.BN254, preImage)
+		if err != nil {
+			t.Fatalf("Failed to hash: %v", err)
+		}
+		a := NewXORShareCircuit(k)
+		a.PreImage, a.Hash = preImage, hash
+		for i, share := range shares {
+			a.Shares[i] = share
+		}
+		return a
+	}
+
+	assert.ProverSucceeded(NewXORShareCircuit(k), assignment(secret), test.WithCurves(ecc.BN254))
+
+	// The sum of the shares is committed correctly but is not their XOR.
+	sum := new(big.Int).Add(shares[0], shares[1])
+	sum.Add(sum, shares[2]).Mod(sum, new(big.Int).Lsh(big.NewInt(1), XORShareBits))
+	assert.ProverFailed(NewXORShareCircuit(k), assignment(sum), test.WithCurves(ecc.BN254))
+}